@@ -0,0 +1,200 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/goschedviz/goschedviz/internal/output"
+)
+
+// traceExtensions are the file extensions batch mode picks up from directories
+var traceExtensions = map[string]bool{".out": true, ".trace": true}
+
+func handleBatch() {
+	fs := flag.NewFlagSet("batch", flag.ExitOnError)
+	jobs := fs.Int("jobs", runtime.NumCPU(), "Number of traces to analyze concurrently")
+	format := fs.String("format", "text", "Output format: "+strings.Join(output.Formats(), ", "))
+	jsonOutput := fs.Bool("json", false, "Output in JSON format (same as --format json)")
+	jsonCompact := fs.Bool("json-compact", false, "Output JSON on a single line without indentation (implies --json)")
+	timeUnit := registerTimeUnitFlag(fs)
+	af := registerAnalysisFlags(fs)
+	parseFlags(fs, os.Args[2:])
+
+	if fs.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz batch [flags] <dir|trace-file>...\n")
+		os.Exit(1)
+	}
+
+	cfg, err := af.config()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	files, err := collectTraceFiles(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(files) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no trace files found (looking for %s)\n", "*.out, *.trace")
+		os.Exit(1)
+	}
+
+	if *jsonOutput || *jsonCompact {
+		*format = "json"
+	}
+	os.Exit(reportBatch(files, cfg, *jobs, *format, output.Options{TimeUnit: timeUnit.unit, Compact: *jsonCompact}))
+}
+
+// reportBatch analyzes files on jobs workers and writes the ranked report in
+// format; it returns the exit code: 2 if any trace failed or has issues
+func reportBatch(files []string, cfg analysisConfig, jobs int, format string, opts output.Options) int {
+	f, err := output.New(format, os.Stdout, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	formatter, ok := f.(output.BatchFormatter)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: format %q has no batch report\n", format)
+		return 1
+	}
+
+	entries := analyzeBatch(files, cfg, jobs)
+	if err := formatter.FormatBatch(entries); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting batch report: %v\n", err)
+		return 1
+	}
+
+	for _, e := range entries {
+		if e.Summary == nil || e.Summary.HasPerformanceIssues {
+			return 2
+		}
+	}
+	return 0
+}
+
+// collectTraceFiles expands globs and directories into the trace files they
+// contain; other arguments are taken as trace files as-is
+func collectTraceFiles(args []string) ([]string, error) {
+	args, err := expandGlobs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if !e.IsDir() && traceExtensions[filepath.Ext(e.Name())] {
+				files = append(files, filepath.Join(arg, e.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+// expandGlobs replaces arguments containing glob metacharacters with the
+// paths they match, so quoted patterns behave the same on every platform
+func expandGlobs(args []string) ([]string, error) {
+	var expanded []string
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			expanded = append(expanded, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", arg)
+		}
+		expanded = append(expanded, matches...)
+	}
+	return expanded, nil
+}
+
+// hasGlobMatches reports whether arg is a glob pattern matching any file
+func hasGlobMatches(arg string) bool {
+	if !strings.ContainsAny(arg, "*?[") {
+		return false
+	}
+	matches, _ := filepath.Glob(arg)
+	return len(matches) > 0
+}
+
+// analyzeBatch analyzes files on a pool of jobs workers, reporting progress on
+// stderr. The result is ranked by issue count, then blocked time, then file
+// name, so it doesn't depend on completion order.
+func analyzeBatch(files []string, cfg analysisConfig, jobs int) []output.BatchEntry {
+	if jobs <= 0 {
+		jobs = 1
+	}
+
+	// The batch has its own progress line
+	cfg.progress = false
+
+	entries := make([]output.BatchEntry, len(files))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for w := 0; w < min(jobs, len(files)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				summary, _, err := parseAndAnalyze(files[i], cfg)
+				entries[i] = output.BatchEntry{File: files[i], Summary: summary, Err: err}
+
+				mu.Lock()
+				done++
+				fmt.Fprintf(os.Stderr, "\r[%d/%d] analyzed %s\033[K", done, len(files), filepath.Base(files[i]))
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range files {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+	fmt.Fprintln(os.Stderr)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if (a.Summary == nil) != (b.Summary == nil) {
+			return a.Summary == nil
+		}
+		if a.Summary != nil {
+			if len(a.Summary.Issues) != len(b.Summary.Issues) {
+				return len(a.Summary.Issues) > len(b.Summary.Issues)
+			}
+			if a.Summary.TotalBlockedTime != b.Summary.TotalBlockedTime {
+				return a.Summary.TotalBlockedTime > b.Summary.TotalBlockedTime
+			}
+		}
+		return a.File < b.File
+	})
+	return entries
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goschedviz/goschedviz/internal/output"
+)
+
+func handleCapture() {
+	fs := flag.NewFlagSet("capture", flag.ExitOnError)
+	execCmd := fs.String("exec", "", "Command to run under the tracer, e.g. 'go test ./store -run TestLoad' (split on whitespace)")
+	keep := fs.String("o", "", "Keep the captured trace at this path (default: a temporary file removed afterwards)")
+	format := fs.String("format", "text", "Output format: "+strings.Join(output.Formats(), ", "))
+	timeUnit := registerTimeUnitFlag(fs)
+	af := registerAnalysisFlags(fs)
+	parseFlags(fs, os.Args[2:])
+
+	args := strings.Fields(*execCmd)
+	if len(args) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz capture --exec '<command>' [flags]\n")
+		os.Exit(1)
+	}
+
+	cfg, err := af.config()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	traceFile, cleanup, err := captureTarget(*keep)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	code := runCapture(args, traceFile, cfg, analyzeOutput{
+		format:       *format,
+		timeUnit:     timeUnit.unit,
+		breakdownMin: 2,
+	})
+	cleanup()
+	os.Exit(code)
+}
+
+// captureTarget returns the absolute path to write the trace to, and a
+// cleanup that removes it unless the user asked to keep it
+func captureTarget(keep string) (string, func(), error) {
+	if keep != "" {
+		path, err := filepath.Abs(keep)
+		return path, func() {}, err
+	}
+	f, err := os.CreateTemp("", "goschedviz-*.out")
+	if err != nil {
+		return "", nil, err
+	}
+	f.Close()
+	return f.Name(), func() { os.Remove(f.Name()) }, nil
+}
+
+// runCapture runs the command with tracing into traceFile, then analyzes the
+// trace; it returns the exit code
+func runCapture(args []string, traceFile string, cfg analysisConfig, out analyzeOutput) int {
+	traced, err := tracedCommand(args, traceFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// The command's own output goes to stderr so stdout carries only the report
+	cmd := exec.Command(traced[0], traced[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	fmt.Fprintf(os.Stderr, "Capturing: %s\n", strings.Join(traced, " "))
+	runErr := cmd.Run()
+
+	// A failing test still leaves a trace worth analyzing
+	if stat, err := os.Stat(traceFile); err != nil || stat.Size() == 0 {
+		if runErr != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s failed without writing a trace: %v\n", traced[0], runErr)
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %s wrote no trace\n", traced[0])
+		}
+		return 1
+	}
+	var exitErr *exec.ExitError
+	if errors.As(runErr, &exitErr) {
+		fmt.Fprintf(os.Stderr, "Warning: %s exited with code %d; analyzing the trace it wrote\n", traced[0], exitErr.ExitCode())
+	} else if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", runErr)
+		return 1
+	}
+
+	summary, goroutines, err := parseAndAnalyze(traceFile, cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := writeReport(os.Stdout, out, summary, goroutines); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting %s output: %v\n", out.format, err)
+		return 1
+	}
+	if summary.HasPerformanceIssues {
+		return 2
+	}
+	return 0
+}
+
+// tracedCommand rewrites args to write an execution trace to traceFile. Only
+// go test can be traced from outside: the runtime has no environment switch
+// that starts the tracer, so any other program has to call trace.Start itself.
+func tracedCommand(args []string, traceFile string) ([]string, error) {
+	if len(args) < 2 || filepath.Base(args[0]) != "go" || args[1] != "test" {
+		return nil, fmt.Errorf("--exec can only trace 'go test' commands; a standalone program has to call runtime/trace.Start itself, or expose net/http/pprof for 'goschedviz dashboard'")
+	}
+	for _, arg := range args[2:] {
+		if arg == "-trace" || strings.HasPrefix(arg, "-trace=") || strings.HasPrefix(arg, "--trace") {
+			return nil, fmt.Errorf("remove %s from the command; capture sets the trace file", arg)
+		}
+	}
+	return slices.Concat(args[:2], []string{"-trace=" + traceFile}, args[2:]), nil
+}
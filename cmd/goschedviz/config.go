@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configFileName is looked up in the working directory, then the home directory
+const configFileName = ".goschedviz.yaml"
+
+// flagDefaults holds flag values from the config file. Top-level keys apply
+// to every command that has a flag of that name; a section named after a
+// command applies only to it and wins over top-level keys:
+//
+//	time-unit: ms
+//	ignore-reasons: [sleep, gc]
+//	analyze:
+//	  format: json
+//	  longest-blocks: 5
+type flagDefaults struct {
+	path     string
+	global   map[string]string
+	commands map[string]map[string]string
+}
+
+// defaults is the config file loaded at startup (nil if there is none)
+var defaults *flagDefaults
+
+// loadDefaults reads the first config file found; it returns nil, nil when
+// neither location has one
+func loadDefaults() (*flagDefaults, error) {
+	var dirs []string
+	if wd, err := os.Getwd(); err == nil {
+		dirs = append(dirs, wd)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, home)
+	}
+
+	for _, dir := range dirs {
+		path := filepath.Join(dir, configFileName)
+		f, err := os.Open(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		d, err := parseDefaults(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		d.path = path
+		return d, nil
+	}
+	return nil, nil
+}
+
+// parseDefaults parses the small YAML subset the config file uses: scalar
+// "key: value" pairs, flow lists like [a, b], and one level of command sections
+func parseDefaults(r io.Reader) (*flagDefaults, error) {
+	d := &flagDefaults{
+		global:   make(map[string]string),
+		commands: make(map[string]map[string]string),
+	}
+
+	var section map[string]string
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\"", n)
+		}
+		key, value = strings.TrimSpace(key), yamlScalar(strings.TrimSpace(value))
+
+		indented := line[0] == ' ' || line[0] == '\t'
+		switch {
+		case indented && section == nil:
+			return nil, fmt.Errorf("line %d: indented key %q outside a command section", n, key)
+		case indented:
+			section[key] = value
+		case value == "":
+			section = make(map[string]string)
+			d.commands[key] = section
+		default:
+			section = nil
+			d.global[key] = value
+		}
+	}
+	return d, scanner.Err()
+}
+
+// yamlScalar unquotes a value and flattens a flow list into a comma list
+func yamlScalar(v string) string {
+	if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") {
+		items := strings.Split(v[1:len(v)-1], ",")
+		for i, item := range items {
+			items[i] = yamlScalar(strings.TrimSpace(item))
+		}
+		return strings.Join(items, ",")
+	}
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// apply sets every flag of fs that the config file covers and the command
+// line left alone
+func (d *flagDefaults) apply(fs *flag.FlagSet) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+
+	set := func(name, value string) error {
+		if explicit[name] {
+			return nil
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("%s: %s: %w", d.path, name, err)
+		}
+		return nil
+	}
+
+	for name, value := range d.global {
+		// Top-level keys are shared, so skip commands without the flag
+		if fs.Lookup(name) == nil {
+			continue
+		}
+		if _, overridden := d.commands[fs.Name()][name]; overridden {
+			continue
+		}
+		if err := set(name, value); err != nil {
+			return err
+		}
+	}
+	for name, value := range d.commands[fs.Name()] {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("%s: %s has no flag %q", d.path, fs.Name(), name)
+		}
+		if err := set(name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFlags parses a subcommand's arguments, then fills in defaults from the
+// config file for flags not given on the command line
+func parseFlags(fs *flag.FlagSet, args []string) {
+	fs.Parse(args)
+	if defaults == nil {
+		return
+	}
+	if err := defaults.apply(fs); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
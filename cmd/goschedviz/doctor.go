@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/output"
+)
+
+func handleDoctor() {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	var headers headerFlag
+	fs.Var(&headers, "header", "Extra HTTP header, e.g. \"Authorization: Bearer <token>\" (repeatable)")
+	fs.Var(&headers, "H", "Extra HTTP header (shorthand)")
+	parseFlags(fs, os.Args[2:])
+
+	target := "http://localhost:6060/debug/pprof/trace?seconds=5"
+	if fs.NArg() == 1 {
+		target = fs.Arg(0)
+	} else if fs.NArg() > 1 {
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz doctor [flags] [<pprof-trace-url>]\n")
+		os.Exit(1)
+	}
+
+	checks := diagnoseLiveSetup(target, headers.header)
+	// Reports get pasted into issues, so a password in the URL is masked
+	shown := target
+	if u, err := url.Parse(target); err == nil {
+		shown = u.Redacted()
+	}
+	formatter := output.NewTextFormatter(os.Stdout)
+	formatter.FormatDoctorReport(shown, checks)
+
+	for _, c := range checks {
+		if !c.OK {
+			os.Exit(1)
+		}
+	}
+}
+
+// diagnoseLiveSetup probes a pprof trace endpoint and reports each setup
+// requirement, stopping at the first check later ones depend on
+func diagnoseLiveSetup(target string, headers http.Header) []output.DoctorCheck {
+	var checks []output.DoctorCheck
+
+	u, err := url.Parse(target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return append(checks, output.DoctorCheck{
+			Name: "URL is valid",
+			Hint: "Use a full URL such as http://localhost:6060/debug/pprof/trace?seconds=5",
+		})
+	}
+	checks = append(checks, output.DoctorCheck{Name: "URL is valid", OK: true})
+
+	if !strings.HasSuffix(u.Path, "/debug/pprof/trace") {
+		checks = append(checks, output.DoctorCheck{
+			Name: "URL points at the trace endpoint",
+			Hint: "The path should end in /debug/pprof/trace (not /profile or /heap, which are different profile types)",
+		})
+	} else {
+		checks = append(checks, output.DoctorCheck{Name: "URL points at the trace endpoint", OK: true})
+	}
+
+	client := http.Client{Timeout: 10 * time.Second}
+	get := func(rawURL string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range headers {
+			for _, v := range values {
+				req.Header.Add(key, v)
+			}
+		}
+		return client.Do(req)
+	}
+
+	// The pprof index only exists when net/http/pprof is imported
+	index := *u
+	index.Path = strings.TrimSuffix(u.Path, "trace")
+	index.RawQuery = ""
+	resp, err := get(index.String())
+	if err != nil {
+		return append(checks, output.DoctorCheck{
+			Name: "Server is reachable",
+			Hint: fmt.Sprintf("Could not connect (%v). Is the app running and listening on %s?", err, u.Host),
+		})
+	}
+	resp.Body.Close()
+	checks = append(checks, output.DoctorCheck{Name: "Server is reachable", OK: true})
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		checks = append(checks, output.DoctorCheck{
+			Name: "Credentials accepted",
+			Hint: "The endpoint requires auth: pass --header \"Authorization: Bearer <token>\" or user:pass@ in the URL",
+		})
+		return checks
+	case resp.StatusCode != http.StatusOK:
+		checks = append(checks, output.DoctorCheck{
+			Name: "net/http/pprof is registered",
+			Hint: fmt.Sprintf("%s returned %s. Add `import _ \"net/http/pprof\"` (or your framework's pprof middleware).", index.Path, resp.Status),
+		})
+	default:
+		checks = append(checks, output.DoctorCheck{Name: "net/http/pprof is registered", OK: true})
+	}
+
+	// Probe the trace endpoint with a short capture to keep the check fast
+	probe := *u
+	q := probe.Query()
+	q.Set("seconds", "1")
+	probe.RawQuery = q.Encode()
+	resp, err = get(probe.String())
+	if err != nil {
+		return append(checks, output.DoctorCheck{
+			Name: "Trace endpoint responds",
+			Hint: err.Error(),
+		})
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return append(checks, output.DoctorCheck{
+			Name: "Trace endpoint responds",
+			Hint: fmt.Sprintf("Returned %s. Check the port and path match your pprof mount point.", resp.Status),
+		})
+	}
+	checks = append(checks, output.DoctorCheck{Name: "Trace endpoint responds", OK: true})
+
+	contentType := resp.Header.Get("Content-Type")
+	if strings.Contains(contentType, "text/html") {
+		checks = append(checks, output.DoctorCheck{
+			Name: "Returns binary trace data",
+			Hint: "Got an HTML page. The URL likely hits a web route or login page rather than /debug/pprof/trace.",
+		})
+		return checks
+	}
+
+	header := make([]byte, 16)
+	n, _ := io.ReadFull(resp.Body, header)
+	if !bytes.HasPrefix(header[:n], []byte("go 1.")) {
+		checks = append(checks, output.DoctorCheck{
+			Name: "Returns binary trace data",
+			Hint: fmt.Sprintf("Response (%s) does not start with a Go trace header: %q", contentType, header[:n]),
+		})
+		return checks
+	}
+
+	return append(checks, output.DoctorCheck{
+		Name:   "Returns binary trace data",
+		OK:     true,
+		Detail: strings.TrimRight(string(header[:n]), "\x00"),
+	})
+}
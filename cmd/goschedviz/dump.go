@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// dumpGo writes v in Go syntax like %#v, except that pointers are followed so
+// nested values print instead of their addresses. Zero-valued fields are left
+// out to keep the dump readable.
+func dumpGo(w io.Writer, v any) {
+	d := &goDumper{w: w, seen: make(map[uintptr]bool)}
+	d.value(reflect.ValueOf(v), 0)
+	fmt.Fprintln(w)
+}
+
+// goDumper tracks the pointers being dumped, so a cycle prints once
+type goDumper struct {
+	w    io.Writer
+	seen map[uintptr]bool
+}
+
+func (d *goDumper) value(v reflect.Value, depth int) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		fmt.Fprint(d.w, "nil")
+	case reflect.Pointer:
+		if v.IsNil() {
+			fmt.Fprint(d.w, "nil")
+			return
+		}
+		if d.seen[v.Pointer()] {
+			fmt.Fprintf(d.w, "(%s)(cycle)", v.Type())
+			return
+		}
+		d.seen[v.Pointer()] = true
+		defer delete(d.seen, v.Pointer())
+		fmt.Fprint(d.w, "&")
+		d.value(v.Elem(), depth)
+	case reflect.Interface:
+		d.value(v.Elem(), depth)
+	case reflect.Struct:
+		d.structValue(v, depth)
+	case reflect.Slice:
+		if v.IsNil() || isScalar(v.Type().Elem()) {
+			fmt.Fprintf(d.w, "%#v", v.Interface())
+			return
+		}
+		fmt.Fprintf(d.w, "%s{\n", v.Type())
+		for i := range v.Len() {
+			d.indent(depth + 1)
+			d.value(v.Index(i), depth+1)
+			fmt.Fprint(d.w, ",\n")
+		}
+		d.indent(depth)
+		fmt.Fprint(d.w, "}")
+	case reflect.Map:
+		if v.IsNil() || isScalar(v.Type().Elem()) {
+			fmt.Fprintf(d.w, "%#v", v.Interface())
+			return
+		}
+		keys := v.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprintf("%#v", a.Interface()), fmt.Sprintf("%#v", b.Interface()))
+		})
+		fmt.Fprintf(d.w, "%s{\n", v.Type())
+		for _, k := range keys {
+			d.indent(depth + 1)
+			fmt.Fprintf(d.w, "%#v: ", k.Interface())
+			d.value(v.MapIndex(k), depth+1)
+			fmt.Fprint(d.w, ",\n")
+		}
+		d.indent(depth)
+		fmt.Fprint(d.w, "}")
+	default:
+		fmt.Fprintf(d.w, "%#v", v.Interface())
+	}
+}
+
+// structValue writes a struct's non-zero fields, one per line. Structs with
+// unexported fields, such as time.Time, can't be walked and use %#v.
+func (d *goDumper) structValue(v reflect.Value, depth int) {
+	t := v.Type()
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			fmt.Fprintf(d.w, "%#v", v.Interface())
+			return
+		}
+	}
+
+	fmt.Fprintf(d.w, "%s{", t)
+	multiline := false
+	for i := range t.NumField() {
+		if v.Field(i).IsZero() {
+			continue
+		}
+		multiline = true
+		fmt.Fprint(d.w, "\n")
+		d.indent(depth + 1)
+		fmt.Fprintf(d.w, "%s: ", t.Field(i).Name)
+		d.value(v.Field(i), depth+1)
+		fmt.Fprint(d.w, ",")
+	}
+	if multiline {
+		fmt.Fprint(d.w, "\n")
+		d.indent(depth)
+	}
+	fmt.Fprint(d.w, "}")
+}
+
+func (d *goDumper) indent(depth int) {
+	fmt.Fprint(d.w, strings.Repeat("\t", depth))
+}
+
+// isScalar reports whether %#v already prints values of t in full
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Struct, reflect.Slice, reflect.Map:
+		return false
+	}
+	return true
+}
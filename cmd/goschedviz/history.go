@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+	"github.com/goschedviz/goschedviz/internal/output"
+)
+
+func handleHistory() {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	historyFile := fs.String("history-file", "", "History file to read (default: user config dir)")
+	parseFlags(fs, os.Args[2:])
+
+	path, err := historyPath(*historyFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	entries, err := output.LoadHistory(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	output.NewTextFormatter(os.Stdout).FormatHistory(entries)
+}
+
+// saveRun appends the summary to the history file
+func saveRun(historyFile, name, traceFile string, summary *model.Summary) error {
+	path, err := historyPath(historyFile)
+	if err != nil {
+		return err
+	}
+	return output.SaveRun(path, name, traceFile, summary)
+}
+
+// historyPath resolves the --history-file flag, falling back to the default
+func historyPath(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	return output.DefaultHistoryPath()
+}
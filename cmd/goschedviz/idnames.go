@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// loadIDNames reads a --replace-ids file of "creation-hash: name" lines
+// (inspect shows each goroutine's creation hash)
+func loadIDNames(path string) (map[string]string, error) {
+	mappings, err := readMappingFile(path)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(mappings))
+	for _, m := range mappings {
+		if prev, dup := names[m.key]; dup && prev != m.value {
+			return nil, fmt.Errorf("%s:%d: hash %s is already named %q", path, m.line, m.key, prev)
+		}
+		names[m.key] = m.value
+	}
+	return names, nil
+}
+
+// applyIDNames labels every goroutine whose creation hash has a name, so the
+// same goroutine reads the same in every report whatever its ID
+func applyIDNames(goroutines map[uint64]*model.GoroutineInfo, names map[string]string) {
+	if len(names) == 0 {
+		return
+	}
+	matched := 0
+	for _, g := range goroutines {
+		if name, ok := names[g.CreationHash]; ok && g.CreationHash != "" {
+			g.Label = name
+			matched++
+		}
+	}
+	if matched == 0 {
+		fmt.Fprintf(os.Stderr, "Warning: no goroutine matched a --replace-ids creation hash\n")
+	}
+}
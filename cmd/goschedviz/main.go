@@ -1,9 +1,19 @@
 package main
 
 import (
+	"cmp"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"maps"
+	"net/http"
 	"os"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,33 +24,46 @@ import (
 )
 
 func main() {
+	var err error
+	if defaults, err = loadDefaults(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config: %v\n", err)
+		os.Exit(1)
+	}
+
 	if len(os.Args) < 2 {
 		// TUI 3.0: Launch Unified Dashboard
-		m := output.NewDashboardModel()
-		if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
-			fmt.Fprintf(os.Stderr, "Error launching dashboard: %v\n", err)
-			os.Exit(1)
-		}
+		runDashboard(nil)
 		return
 	}
 
 	subcommand := os.Args[1]
 	switch subcommand {
+	case "dashboard":
+		handleDashboard()
 	case "analyze":
 		handleAnalyze()
 	case "insights":
 		handleInsights()
+	case "batch":
+		handleBatch()
+	case "capture":
+		handleCapture()
+	case "history":
+		handleHistory()
 	case "inspect":
 		handleInspect()
 	case "explore":
 		handleExplore()
+	case "doctor":
+		handleDoctor()
 	case "version":
 		printVersion()
 	case "help", "-h", "--help":
 		printGeneralUsage()
 	default:
-		// Backward compatibility: if the first arg is a file, run analyze
-		if _, err := os.Stat(subcommand); err == nil {
+		// Backward compatibility: if the first arg is a file (or a glob
+		// matching files), run analyze
+		if _, err := os.Stat(subcommand); err == nil || hasGlobMatches(subcommand) {
 			handleAnalyzeLegacy(os.Args[1:])
 			return
 		}
@@ -64,69 +87,412 @@ func printGeneralUsage() {
 	fmt.Println("Commands:")
 	fmt.Printf("  %-10s %s\n", "analyze", "Standard metrics & performance markers")
 	fmt.Printf("  %-10s %s\n", "insights", "Narrative analysis and optimization suggestions")
-	fmt.Printf("  %-10s %s\n", "inspect", "Deep-dive into a specific goroutine (--gid)")
+	fmt.Printf("  %-10s %s\n", "batch", "Analyze a directory of traces concurrently (--jobs)")
+	fmt.Printf("  %-10s %s\n", "capture", "Run 'go test' under the tracer and analyze the result (--exec)")
+	fmt.Printf("  %-10s %s\n", "history", "List runs saved with analyze --save-run")
+	fmt.Printf("  %-10s %s\n", "inspect", "Deep-dive into a goroutine, or compare several (--gid 42,99)")
 	fmt.Printf("  %-10s %s\n", "explore", "Interactive TUI dashboard for trace exploration")
+	fmt.Printf("  %-10s %s\n", "dashboard", "Launch the dashboard (--header for secured live capture)")
+	fmt.Printf("  %-10s %s\n", "doctor", "Diagnose live-capture setup for a pprof URL")
 	fmt.Printf("  %-10s %s\n", "version", "Print current version")
 
 	fmt.Printf("\nRun 'goschedviz <command> --help' for flags.\n")
 }
 
+// analysisConfig carries parser and analyzer options parsed from command-line flags
+type analysisConfig struct {
+	ignoreReasons  []model.BlockingReason
+	focusReasons   []model.BlockingReason
+	capRatio       float64
+	stateIntervals bool
+	stacks         bool
+	workers        int
+	maxEvents      int
+	longestBlocks  int
+	chains         bool
+	reasonCycles   bool
+	skipInit       time.Duration
+	since          time.Duration
+	until          time.Duration
+	excludeMain    bool
+	excludeIdle    bool
+	keepTop        int
+	topN           int
+	precision      bool
+	noiseFloor     time.Duration
+	verbose        bool
+	progress       bool
+	reasonMap      []traceparser.ReasonOverride
+	idNames        map[string]string
+}
+
+// parserOptions returns the traceparser options the config requires
+func (c analysisConfig) parserOptions() []traceparser.Option {
+	opts := []traceparser.Option{
+		traceparser.WithWorkers(c.workers),
+		traceparser.WithMaxEventsPerGoroutine(c.maxEvents),
+	}
+	if c.stateIntervals {
+		opts = append(opts, traceparser.WithStateIntervals())
+	}
+	if c.reasonCycles {
+		opts = append(opts, traceparser.WithReasonSequence())
+	}
+	if c.stacks {
+		opts = append(opts, traceparser.WithStacks())
+	}
+	if c.verbose {
+		opts = append(opts, traceparser.WithValidation())
+	}
+	if len(c.reasonMap) > 0 {
+		opts = append(opts, traceparser.WithReasonOverrides(c.reasonMap))
+	}
+	return opts
+}
+
+// apply configures the analyzer with the options
+func (c analysisConfig) apply(a *analyzer.Analyzer) {
+	if len(c.ignoreReasons) > 0 {
+		a.SetIgnoredReasons(c.ignoreReasons)
+	}
+	if len(c.focusReasons) > 0 {
+		a.SetFocusReasons(c.focusReasons)
+	}
+	a.SetConcurrencyRatio(c.capRatio)
+	a.SetLongestBlocks(c.longestBlocks)
+	a.SetTopN(c.topN)
+	a.SetBlockingChains(c.chains)
+	a.SetReasonCycles(c.reasonCycles)
+	a.SetSkipInit(c.skipInit)
+	a.SetWindow(c.since, c.until)
+	a.SetNoiseFloor(c.noiseFloor)
+	a.SetExcludeMain(c.excludeMain)
+	a.SetExcludeIdle(c.excludeIdle)
+}
+
+// topNUsage documents --top-n, which analyze and inspect both accept
+const topNUsage = "List the N most blocked goroutines, and up to N events per goroutine in inspect (0 for all)"
+
+// stacksUsage documents --stacks, which analyze and inspect both accept
+const stacksUsage = "Capture the stack of each blocking event, to show where goroutines blocked (slower parse, more memory)"
+
+// analysisFlags registers the flags shared by commands that run the analyzer
+type analysisFlags struct {
+	ignoreSleep   *bool
+	ignoreReasons *string
+	reasons       *string
+	capRatio      *float64
+	workers       *int
+	maxEvents     *int
+	skipInit      *time.Duration
+	since         *time.Duration
+	until         *time.Duration
+	excludeMain   *bool
+	excludeIdle   *bool
+	keepTop       *int
+	topN          *int
+	stacks        *bool
+	precision     *bool
+	noiseFloor    *time.Duration
+	verbose       *bool
+	reasonMap     *string
+	replaceIDs    *string
+}
+
+func registerAnalysisFlags(fs *flag.FlagSet) *analysisFlags {
+	return &analysisFlags{
+		ignoreSleep:   fs.Bool("ignore-sleep", false, "Exclude sleep/timer blocking from blocked totals"),
+		ignoreReasons: fs.String("ignore-reasons", "", "Comma-separated blocking reasons to exclude (e.g. chan,gc,sleep)"),
+		reasons:       fs.String("reason", "", "Only analyze goroutines whose blocking is dominated by these comma-separated reasons (e.g. mutex,chan)"),
+		capRatio:      fs.Float64("concurrency-ratio", analyzer.DefaultConcurrencyRatio, "Recommend a concurrency cap when peak goroutines exceed this multiple of those running or runnable at once (0 disables)"),
+		workers:       fs.Int("workers", 0, "Number of parse workers (default: number of CPUs)"),
+		maxEvents:     fs.Int("max-events-per-goroutine", 0, "Keep only the N longest blocking events per goroutine to bound memory (0 keeps all)"),
+		skipInit:      fs.Duration("skip-init", 0, "Exclude blocking in the first DURATION of the trace (startup phase), e.g. 500ms"),
+		since:         fs.Duration("since", 0, "Only count blocking after this offset into the trace, e.g. 2s; blocks straddling it are clipped"),
+		until:         fs.Duration("until", 0, "Only count blocking before this offset into the trace, e.g. 3s; must be after --since"),
+		excludeMain:   fs.Bool("exclude-main", false, "Exclude the main goroutine (#1) from all statistics"),
+		keepTop:       fs.Int("keep-top", 0, "After analysis keep per-goroutine detail only for the K most blocked goroutines to bound memory; the summary still covers every goroutine (0 keeps all)"),
+		topN:          fs.Int("top-n", analyzer.DefaultTopN, topNUsage),
+		stacks:        fs.Bool("stacks", false, stacksUsage),
+		excludeIdle:   fs.Bool("exclude-idle", false, "Exclude goroutines that never ran during the trace (e.g. parked listeners and pool workers)"),
+		noiseFloor:    fs.Duration("noise-floor", 0, "Discard blocking events shorter than DURATION as scheduler noise, e.g. 10us"),
+		verbose:       fs.Bool("verbose", false, "Validate goroutine state transitions and report impossible ones on stderr"),
+		reasonMap:     fs.String("reason-map", "", "File of \"substring: reason\" lines classifying trace wait reasons ahead of the built-in rules"),
+		replaceIDs:    fs.String("replace-ids", "", "File of \"creation-hash: name\" lines labeling goroutines consistently across captures (inspect shows the hashes)"),
+		precision:     fs.Bool("precision-warnings", false, "Warn when trace timestamp precision limits how far very short blocks can be trusted"),
+	}
+}
+
+// config converts the parsed flag values into an analysisConfig
+func (af *analysisFlags) config() (analysisConfig, error) {
+	cfg := analysisConfig{
+		capRatio:    *af.capRatio,
+		workers:     *af.workers,
+		maxEvents:   *af.maxEvents,
+		skipInit:    *af.skipInit,
+		since:       *af.since,
+		until:       *af.until,
+		excludeMain: *af.excludeMain,
+		excludeIdle: *af.excludeIdle,
+		keepTop:     *af.keepTop,
+		topN:        *af.topN,
+		stacks:      *af.stacks,
+		precision:   *af.precision,
+		noiseFloor:  *af.noiseFloor,
+		verbose:     *af.verbose,
+		progress:    true,
+	}
+	if cfg.since < 0 || cfg.until < 0 {
+		return cfg, fmt.Errorf("--since and --until must not be negative")
+	}
+	if cfg.until > 0 && cfg.until <= cfg.since {
+		return cfg, fmt.Errorf("--until (%s) must be after --since (%s)", cfg.until, cfg.since)
+	}
+	if cfg.maxEvents > 0 && (cfg.skipInit > 0 || cfg.since > 0 || cfg.until > 0 || cfg.noiseFloor > 0) {
+		return cfg, fmt.Errorf("--max-events-per-goroutine can't be combined with --skip-init, --since, --until or --noise-floor, which need every event")
+	}
+	if *af.reasonMap != "" {
+		overrides, err := loadReasonMap(*af.reasonMap)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.reasonMap = overrides
+	}
+	if *af.replaceIDs != "" {
+		names, err := loadIDNames(*af.replaceIDs)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.idNames = names
+	}
+	if *af.ignoreReasons != "" {
+		reasons, err := model.ParseBlockingReasons(*af.ignoreReasons)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.ignoreReasons = reasons
+	}
+	if *af.reasons != "" {
+		reasons, err := model.ParseBlockingReasons(*af.reasons)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.focusReasons = reasons
+	}
+	if *af.ignoreSleep {
+		cfg.ignoreReasons = append(cfg.ignoreReasons, model.BlockSleep)
+	}
+	return cfg, nil
+}
+
 func handleAnalyze() {
 	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
-	jsonOutput := fs.Bool("json", false, "Output in JSON format")
+	jsonOutput := fs.Bool("json", false, "Output in JSON format (same as --format json)")
+	jsonCompact := fs.Bool("json-compact", false, "Write JSON on a single line without indentation (implies --json unless --json-out is set)")
+	exemplars := fs.Bool("exemplars", false, "Attach a sample goroutine ID to each blocking counter in --format openmetrics")
+	format := fs.String("format", "text", "Output format: "+strings.Join(analyzeFormats(), ", ")+" (dot is the wait-for graph, badge a shields.io endpoint, perfetto Chrome trace JSON); comma-separated with --json-out")
+	jsonOut := fs.String("json-out", "", "Also write the JSON report to this file (e.g. --format text,json --json-out report.json)")
+	badge := fs.Bool("badge", false, "Output a shields.io endpoint badge JSON (same as --format badge)")
+	csvOutput := fs.Bool("csv", false, "Output every goroutine as CSV with nanosecond durations (same as --format csv)")
 	topBlocked := fs.Bool("top", false, "Show only top blocked goroutines")
+	contentionCSV := fs.String("contention-csv", "", "Write running/runnable/blocked goroutines per time window to this CSV file")
+	scatterCSV := fs.String("scatter-csv", "", "Write per-goroutine running/runnable/blocked nanoseconds to this CSV file")
+	assertions := fs.String("assertions", "", "Write each health rule's value, threshold and pass/fail to this JSON file, for tests to check")
+	longestBlocks := fs.Int("longest-blocks", 0, "Show the N longest individual blocking events across all goroutines")
+	chains := fs.Bool("chains", false, "Reconstruct causal blocking chains across goroutines (A waits on B, blocked on C, ...)")
+	timeUnit := registerTimeUnitFlag(fs)
+	chromeTrace := fs.String("chrome-trace", "", "Write each goroutine's blocking events as Chrome Trace Event JSON to this file, for https://ui.perfetto.dev")
+	sqlitePath := fs.String("sqlite", "", "Insert the summary and per-goroutine rows into this SQLite database, creating its tables if absent")
+	saveRun := fs.String("save-run", "", "Save this run's summary to the history under NAME (see 'goschedviz history')")
+	historyFile := fs.String("history-file", "", "History file for --save-run (default: user config dir)")
+	reasonCycles := fs.Bool("reason-cycles", false, "Detect goroutines alternating between two blocking reasons (e.g. syscall ⇄ channel receive)")
+	breakdownMinPct := fs.Float64("breakdown-min-pct", 2, "Collapse blocking reasons below this percentage into an \"other\" row (0 to show all)")
+	benchmark := fs.Bool("benchmark", false, "Report parse throughput and exit")
+	dumpSummary := fs.Bool("dump-summary", false, "Print the computed Summary in Go syntax to stderr (for bug reports)")
+	pprofOut := fs.String("pprof-out", "", "Write a CPU profile of goschedviz itself to this file")
+	hideFlags(fs, "benchmark", "dump-summary", "pprof-out")
 	watch := fs.Bool("watch", false, "Watch trace file for changes and re-analyze")
 	fs.BoolVar(watch, "w", false, "Watch trace file for changes and re-analyze (shorthand)")
-	fs.Parse(os.Args[2:])
+	watchInterval := fs.Duration("watch-interval", defaultWatchInterval, "How often --watch polls the trace file")
+	af := registerAnalysisFlags(fs)
+	parseFlags(fs, os.Args[2:])
 
-	if fs.NArg() != 1 {
-		fmt.Fprintf(os.Stderr, "Usage: goschedviz analyze [flags] <trace-file>\n")
+	if fs.NArg() == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz analyze [flags] <trace-file|glob>...\n")
 		os.Exit(1)
 	}
 
-	traceFile := fs.Arg(0)
+	cfg, err := af.config()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	files, err := expandGlobs(fs.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	exit := os.Exit
+	if *pprofOut != "" {
+		if *watch {
+			fmt.Fprintf(os.Stderr, "Error: --pprof-out cannot be combined with --watch\n")
+			os.Exit(1)
+		}
+		stop, err := startCPUProfile(*pprofOut)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// os.Exit skips deferred calls, so every exit below stops it explicitly
+		defer stop()
+		exit = func(code int) {
+			stop()
+			os.Exit(code)
+		}
+	}
+
+	if *benchmark {
+		if err := runParseBenchmark(files[0], cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		return
+	}
+
+	if *jsonOutput || (*jsonCompact && *jsonOut == "") {
+		*format = "json"
+	}
+	if *badge {
+		*format = "badge"
+	}
+	if *csvOutput {
+		*format = "csv"
+	}
+	stdoutFormat, err := selectFormats(*format, *jsonOut)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	out := analyzeOutput{
+		topOnly:       *topBlocked,
+		format:        stdoutFormat,
+		jsonOut:       *jsonOut,
+		jsonCompact:   *jsonCompact,
+		exemplars:     *exemplars,
+		contentionCSV: *contentionCSV,
+		scatterCSV:    *scatterCSV,
+		chromeTrace:   *chromeTrace,
+		assertions:    *assertions,
+		breakdownMin:  *breakdownMinPct,
+		timeUnit:      timeUnit.unit,
+		saveRun:       *saveRun,
+		historyFile:   *historyFile,
+		sqlite:        *sqlitePath,
+		dumpSummary:   *dumpSummary,
+	}
+	cfg.stateIntervals = out.contentionCSV != "" || out.format == "perfetto"
+	cfg.longestBlocks = *longestBlocks
+	cfg.chains = *chains
+	cfg.reasonCycles = *reasonCycles
+
+	// Several traces are ranked against each other like batch does
+	if len(files) > 1 {
+		if *watch {
+			fmt.Fprintf(os.Stderr, "Error: --watch needs a single trace file\n")
+			exit(1)
+		}
+		exit(reportBatch(files, cfg, runtime.NumCPU(), cmp.Or(stdoutFormat, "json"), output.Options{
+			TimeUnit:        out.timeUnit,
+			BreakdownMinPct: out.breakdownMin,
+			Compact:         out.jsonCompact,
+		}))
+	}
+
+	traceFile := files[0]
+	var verdict string
+	var issues bool
 	action := func() bool {
-		return runAnalysis(traceFile, *topBlocked, *jsonOutput)
+		summary, ok := runAnalysis(traceFile, cfg, out)
+		if summary != nil {
+			verdict = analyzer.Verdict(summary)
+			issues = summary.HasPerformanceIssues
+		}
+		return ok
 	}
 
 	if *watch {
-		watchFile(traceFile, action)
+		watchFile(traceFile, *watchInterval, out.format == "ndjson", action, func() string { return verdict })
 		return
 	}
 
 	if !action() {
-		fmt.Println("\n✖ Performance issues detected (exit code 2)")
-		os.Exit(2)
+		// The status goes to stderr so stdout stays pure for machine formats
+		if issues {
+			fmt.Fprintln(os.Stderr, "\n✖ Performance issues detected (exit code 2)")
+		}
+		exit(2)
 	}
 }
 
 func handleInsights() {
 	fs := flag.NewFlagSet("insights", flag.ExitOnError)
+	format := fs.String("format", "text", "Output format: "+strings.Join(output.Formats(), ", "))
+	timeUnit := registerTimeUnitFlag(fs)
 	watch := fs.Bool("watch", false, "Watch trace file for changes and re-analyze")
 	fs.BoolVar(watch, "w", false, "Watch trace file for changes and re-analyze (shorthand)")
-	fs.Parse(os.Args[2:])
+	watchInterval := fs.Duration("watch-interval", defaultWatchInterval, "How often --watch polls the trace file")
+	af := registerAnalysisFlags(fs)
+	parseFlags(fs, os.Args[2:])
 
 	if fs.NArg() != 1 {
 		fmt.Fprintf(os.Stderr, "Usage: goschedviz insights <trace-file>\n")
 		os.Exit(1)
 	}
 
+	cfg, err := af.config()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	traceFile := fs.Arg(0)
+	formatter, err := output.New(*format, os.Stdout, output.Options{TimeUnit: timeUnit.unit})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	// The text report frames the insights with the verdict and ranked fixes
+	text, _ := formatter.(*output.TextFormatter)
 
+	var verdict string
 	action := func() bool {
-		summary, _, err := parseAndAnalyze(traceFile)
+		summary, _, err := parseAndAnalyze(traceFile, cfg)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			return false
 		}
 		insights := analyzer.GenerateInsights(summary)
-		formatter := output.NewFormatter(os.Stdout)
-		formatter.FormatInsights(insights)
+		verdict = analyzer.Verdict(summary)
+		if text != nil {
+			text.FormatTraceHeader(summary)
+			text.FormatVerdict(verdict)
+		}
+		if err := formatter.FormatInsights(insights); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting insights: %v\n", err)
+			return false
+		}
+		if text != nil {
+			text.FormatRecommendations(analyzer.RankRecommendations(summary))
+			text.FormatVerdict(verdict)
+		}
 		return true
 	}
 
 	if *watch {
-		watchFile(traceFile, action)
+		watchFile(traceFile, *watchInterval, *format == "ndjson", action, func() string { return verdict })
 		return
 	}
 	if !action() {
@@ -134,89 +500,238 @@ func handleInsights() {
 	}
 }
 
-func watchFile(path string, action func() bool) {
-	lastMod := time.Time{}
-
-	fmt.Printf("👀 Watching %s for changes... (Ctrl+C to stop)\n", path)
-
-	for {
-		stat, err := os.Stat(path)
-		if err != nil {
-			time.Sleep(1 * time.Second)
-			continue
-		}
-
-		if stat.ModTime().After(lastMod) {
-			// Clear screen for a clean update
-			fmt.Print("\033[H\033[2J")
-			action()
-			lastMod = stat.ModTime()
-			fmt.Printf("\n👀 Last updated: %s. Watching for changes...\n", lastMod.Format("15:04:05"))
-		}
-
-		time.Sleep(500 * time.Millisecond)
-	}
-}
-
 func handleInspect() {
 	fs := flag.NewFlagSet("inspect", flag.ExitOnError)
-	gid := fs.Uint64("gid", 0, "Goroutine ID to inspect")
-	jsonOutput := fs.Bool("json", false, "Output in JSON format")
-	fs.Parse(os.Args[2:])
+	gidList := fs.String("gid", "", "Goroutine ID to inspect, or a comma-separated list to compare side by side")
+	format := fs.String("format", "text", "Output format: "+strings.Join(output.Formats(), ", "))
+	jsonOutput := fs.Bool("json", false, "Output in JSON format (same as --format json)")
+	jsonCompact := fs.Bool("json-compact", false, "Output JSON on a single line without indentation (implies --json)")
+	timeUnit := registerTimeUnitFlag(fs)
+	absoluteTime := fs.Bool("absolute-time", false, "Show event timestamps as wall-clock times (needs a Go 1.25+ trace)")
+	jsonEvents := fs.Int("json-events", 0, "Include up to N blocking events (start, duration, reason) per goroutine in JSON, keeping the longest; -1 for all")
+	replaceIDs := fs.String("replace-ids", "", "File of \"creation-hash: name\" lines labeling goroutines consistently across captures")
+	topN := fs.Int("top-n", analyzer.DefaultTopN, topNUsage)
+	stacks := fs.Bool("stacks", false, stacksUsage)
+	parseFlags(fs, os.Args[2:])
 
-	if fs.NArg() != 1 || *gid == 0 {
-		fmt.Fprintf(os.Stderr, "Usage: goschedviz inspect --gid <id> <trace-file>\n")
+	gids, err := parseGoroutineIDs(*gidList)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if fs.NArg() != 1 || len(gids) == 0 {
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz inspect --gid <id>[,<id>...] <trace-file>\n")
 		os.Exit(1)
 	}
 
-	_, goroutines, err := parseAndAnalyze(fs.Arg(0))
+	cfg := analysisConfig{topN: *topN, stacks: *stacks}
+	if *replaceIDs != "" {
+		if cfg.idNames, err = loadIDNames(*replaceIDs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	summary, goroutines, err := parseAndAnalyze(fs.Arg(0), cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	g, exists := goroutines[*gid]
-	if !exists {
-		fmt.Fprintf(os.Stderr, "Error: goroutine #%d not found\n", *gid)
-		os.Exit(1)
+	selected := make([]*model.GoroutineInfo, 0, len(gids))
+	for _, gid := range gids {
+		g, exists := goroutines[gid]
+		if !exists {
+			fmt.Fprintf(os.Stderr, "Error: goroutine #%d not found\n", gid)
+			os.Exit(1)
+		}
+		selected = append(selected, g)
 	}
 
-	var formatter interface {
-		FormatGoroutineDetail(*model.GoroutineInfo) error
+	if *jsonOutput || *jsonCompact {
+		*format = "json"
 	}
-	if *jsonOutput {
-		formatter = output.NewJSONFormatter(os.Stdout)
-	} else {
-		formatter = output.NewFormatter(os.Stdout)
+	if *absoluteTime && summary.Clock == nil {
+		fmt.Fprintln(os.Stderr, "Warning: trace has no wall-clock reference (requires Go 1.25+), showing trace-relative times")
+	}
+	opts := output.Options{TimeUnit: timeUnit.unit, Compact: *jsonCompact, Events: *jsonEvents, TopN: *topN}
+	if *absoluteTime {
+		opts.Clock = summary.Clock
+	}
+	formatter, err := output.New(*format, os.Stdout, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	if err := formatter.FormatGoroutineDetail(g); err != nil {
+	if c, ok := formatter.(output.Comparer); ok && len(selected) > 1 {
+		err = c.FormatGoroutineComparison(selected)
+	} else {
+		for _, g := range selected {
+			if err = formatter.FormatGoroutineDetail(g); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error formatting detail: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// parseGoroutineIDs parses a comma-separated list of goroutine IDs, dropping
+// duplicates but keeping the order given
+func parseGoroutineIDs(list string) ([]uint64, error) {
+	var ids []uint64
+	seen := make(map[uint64]bool)
+	for _, field := range strings.Split(list, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(field, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("invalid goroutine ID %q", field)
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
 func handleExplore() {
 	fs := flag.NewFlagSet("explore", flag.ExitOnError)
-	fs.Parse(os.Args[2:])
+	playback := fs.Bool("playback", false, "Record per-goroutine state over time to enable playback (p); uses more memory")
+	af := registerAnalysisFlags(fs)
+	parseFlags(fs, os.Args[2:])
 
 	if fs.NArg() != 1 {
-		fmt.Fprintf(os.Stderr, "Usage: goschedviz explore <trace-file>\n")
+		fmt.Fprintf(os.Stderr, "Usage: goschedviz explore [flags] <trace-file>\n")
 		os.Exit(1)
 	}
 
-	summary, goroutines, err := parseAndAnalyze(fs.Arg(0))
+	cfg, err := af.config()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	cfg.stateIntervals = *playback
 
-	if err := output.StartTUI(summary, goroutines); err != nil {
+	// The parse is kept so the explorer's toggles only re-run the analyzer
+	result, info, err := parseTrace(fs.Arg(0), cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	reanalyze := func(t output.AnalysisToggles) (*model.Summary, map[uint64]*model.GoroutineInfo) {
+		c := cfg
+		c.excludeMain, c.excludeIdle, c.noiseFloor = t.ExcludeMain, t.ExcludeIdle, t.NoiseFloor
+		return analyzeParsed(result, info, c)
+	}
+	toggles := output.AnalysisToggles{ExcludeMain: cfg.excludeMain, ExcludeIdle: cfg.excludeIdle, NoiseFloor: cfg.noiseFloor, EventsCapped: cfg.maxEvents > 0}
+	summary, goroutines := reanalyze(toggles)
+
+	if err := output.StartTUI(summary, goroutines, reanalyze, toggles); err != nil {
 		fmt.Fprintf(os.Stderr, "Error launching TUI: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+// headerFlag collects repeated "Key: Value" HTTP header flags
+type headerFlag struct {
+	header http.Header
+}
+
+func (h *headerFlag) String() string {
+	if h.header == nil {
+		return ""
+	}
+	keys := make([]string, 0, len(h.header))
+	for k := range h.header {
+		keys = append(keys, k)
+	}
+	return strings.Join(keys, ",")
+}
+
+func (h *headerFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("header must be in \"Key: Value\" form, got %q", value)
+	}
+	if h.header == nil {
+		h.header = make(http.Header)
+	}
+	h.header.Add(strings.TrimSpace(key), strings.TrimSpace(val))
+	return nil
+}
+
+// timeUnitFlag validates --time-unit as it is parsed
+type timeUnitFlag struct {
+	unit output.TimeUnit
+}
+
+func (t *timeUnitFlag) String() string {
+	return string(t.unit)
+}
+
+func (t *timeUnitFlag) Set(value string) error {
+	unit, err := output.ParseTimeUnit(value)
+	if err != nil {
+		return err
+	}
+	t.unit = unit
+	return nil
+}
+
+// registerTimeUnitFlag adds --time-unit, defaulting to auto-scaling
+func registerTimeUnitFlag(fs *flag.FlagSet) *timeUnitFlag {
+	t := &timeUnitFlag{unit: output.UnitAuto}
+	fs.Var(t, "time-unit", "Unit for durations in text output: ns, us, ms, s or auto")
+	return t
+}
+
+func handleDashboard() {
+	fs := flag.NewFlagSet("dashboard", flag.ExitOnError)
+	var headers headerFlag
+	fs.Var(&headers, "header", "Extra HTTP header for live capture, e.g. \"X-Token: abc\" (repeatable)")
+	fs.Var(&headers, "H", "Extra HTTP header for live capture (shorthand)")
+	auth := fs.String("auth", "", "Authorization header value for live capture, e.g. \"Bearer <token>\"")
+	parseFlags(fs, os.Args[2:])
+
+	if *auth != "" {
+		if headers.header.Get("Authorization") != "" {
+			fmt.Fprintln(os.Stderr, "Error: --auth and an Authorization --header can't be combined")
+			os.Exit(1)
+		}
+		if headers.header == nil {
+			headers.header = make(http.Header)
+		}
+		headers.header.Set("Authorization", *auth)
+	}
+	runDashboard(headers.header)
+}
+
+func runDashboard(headers http.Header) {
+	m := output.NewDashboardModel()
+	if headers != nil {
+		m.SetHeaders(headers)
+	}
+	final, err := tea.NewProgram(m, tea.WithAltScreen()).Run()
+	output.RemoveTempTraces()
+	// An interrupt is a normal way to leave, not a failure
+	if err != nil && !errors.Is(err, tea.ErrInterrupted) {
+		fmt.Fprintf(os.Stderr, "Error launching dashboard: %v\n", err)
+		os.Exit(1)
+	}
+
+	if d, ok := final.(output.DashboardModel); ok {
+		if n, verdict := d.SessionSummary(); n > 0 {
+			fmt.Printf("Analyzed %d trace(s) this session. %s\n", n, verdict)
+		}
+	}
+}
+
 func handleAnalyzeLegacy(args []string) {
 	// Support old-style: goschedviz [flags] file
 	// Actually, easier to just redirect to analyze
@@ -224,44 +739,330 @@ func handleAnalyzeLegacy(args []string) {
 	handleAnalyze()
 }
 
-func parseAndAnalyze(traceFile string) (*model.Summary, map[uint64]*model.GoroutineInfo, error) {
+func parseAndAnalyze(traceFile string, cfg analysisConfig) (*model.Summary, map[uint64]*model.GoroutineInfo, error) {
+	result, info, err := parseTrace(traceFile, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	summary, goroutines := analyzeParsed(result, info, cfg)
+	if cfg.keepTop > 0 {
+		// The summary is complete; only the per-goroutine detail is bounded
+		goroutines, summary.PrunedGoroutines = analyzer.KeepTop(goroutines, cfg.keepTop)
+	}
+	return summary, goroutines, nil
+}
+
+// parseTrace parses the trace file and describes it
+func parseTrace(traceFile string, cfg analysisConfig) (*traceparser.ParseResult, *model.TraceInfo, error) {
 	f, err := os.Open(traceFile)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
 	}
 	defer f.Close()
 
-	parser := traceparser.NewParser()
-	result, err := parser.Parse(f)
-	if err != nil {
+	opts := cfg.parserOptions()
+	var r io.Reader = f
+	var progress *parseProgress
+	if cfg.progress {
+		progress = newParseProgress(f)
+	}
+	if progress != nil {
+		r = progress.bytes
+		opts = append(opts, traceparser.WithProgress(progress.update))
+	}
+
+	parser := traceparser.NewParser(opts...)
+	result, err := parser.Parse(r)
+	if progress != nil {
+		progress.done()
+	}
+	if err != nil && !errors.Is(err, traceparser.ErrPartialTrace) {
 		return nil, nil, fmt.Errorf("failed to parse trace: %w", err)
 	}
+	if len(result.Errors) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: %d trace events could not be processed (first: %v)\n", len(result.Errors), result.Errors[0])
+	}
+	if cfg.verbose {
+		reportValidation(result.InvalidTransitions)
+		reportUnmappedReasons(result.UnmappedReasons)
+	}
+	if cfg.precision {
+		for _, w := range precisionWarnings(result.Precision, result.Goroutines) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+	}
+
+	applyIDNames(result.Goroutines, cfg.idNames)
 
+	info := &model.TraceInfo{
+		File:          traceFile,
+		GoVersion:     result.GoVersion,
+		GOMAXPROCS:    result.GOMAXPROCS,
+		Events:        result.EventCount,
+		SkippedEvents: result.SkippedEvents,
+	}
+	if stat, err := f.Stat(); err == nil {
+		info.Size = stat.Size()
+	}
+	return result, info, nil
+}
+
+// analyzeParsed analyzes a parsed trace, returning the summary and the
+// goroutines it covered after the configured exclusions
+func analyzeParsed(result *traceparser.ParseResult, info *model.TraceInfo, cfg analysisConfig) (*model.Summary, map[uint64]*model.GoroutineInfo) {
 	a := analyzer.NewAnalyzer(result.Goroutines)
+	a.SetGOMAXPROCS(result.GOMAXPROCS)
+	a.SetGCActivity(result.GC)
+	a.SetBusyProcs(result.BusyProcs)
+	a.SetPeakRunnable(result.PeakRunnable)
+	cfg.apply(a)
 	summary := a.Analyze()
-	return summary, result.Goroutines, nil
+	summary.Incomplete = result.Partial
+	summary.Clock = result.Clock
+	summary.Trace = info
+	return summary, a.Goroutines()
+}
+
+// reportValidation prints the impossible state transitions found, by kind
+func reportValidation(invalid map[string]int) {
+	total := 0
+	for _, n := range invalid {
+		total += n
+	}
+	fmt.Fprintf(os.Stderr, "Validation: %d impossible state transitions\n", total)
+	for _, kind := range slices.Sorted(maps.Keys(invalid)) {
+		fmt.Fprintf(os.Stderr, "  %-40s %d\n", kind, invalid[kind])
+	}
 }
 
-func runAnalysis(traceFile string, topOnly bool, jsonFormat bool) bool {
-	summary, _, err := parseAndAnalyze(traceFile)
+// runParseBenchmark parses the trace once and reports throughput
+func runParseBenchmark(traceFile string, cfg analysisConfig) error {
+	f, err := os.Open(traceFile)
+	if err != nil {
+		return fmt.Errorf("failed to open trace file: %w", err)
+	}
+	defer f.Close()
+
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	parser := traceparser.NewParser(cfg.parserOptions()...)
+	start := time.Now()
+	result, err := parser.Parse(f)
+	elapsed := time.Since(start)
+	if err != nil && !errors.Is(err, traceparser.ErrPartialTrace) {
+		return fmt.Errorf("failed to parse trace: %w", err)
+	}
+
+	mb := float64(stat.Size()) / (1 << 20)
+	secs := elapsed.Seconds()
+	fmt.Printf("Parsed %d events (%.1f MB, %d goroutines) in %s\n", result.EventCount, mb, len(result.Goroutines), elapsed.Round(time.Millisecond))
+	fmt.Printf("Throughput: %.0f events/sec, %.2f MB/sec\n", float64(result.EventCount)/secs, mb/secs)
+	return nil
+}
+
+// startCPUProfile profiles goschedviz itself into path until stop is called
+func startCPUProfile(path string) (stop func(), err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating CPU profile: %w", err)
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
+	}
+	return func() {
+		pprof.StopCPUProfile()
+		f.Close()
+	}, nil
+}
+
+// hideFlags replaces the flag set's usage so the named flags stay undocumented
+func hideFlags(fs *flag.FlagSet, names ...string) {
+	hidden := make(map[string]bool, len(names))
+	for _, n := range names {
+		hidden[n] = true
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage of %s:\n", fs.Name())
+		visible := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+		fs.VisitAll(func(f *flag.Flag) {
+			if !hidden[f.Name] {
+				visible.Var(f.Value, f.Name, f.Usage)
+			}
+		})
+		visible.SetOutput(fs.Output())
+		visible.PrintDefaults()
+	}
+}
+
+// analyzeOutput selects what the analyze command emits
+type analyzeOutput struct {
+	topOnly       bool
+	format        string // written to stdout; empty when JSON only goes to jsonOut
+	jsonOut       string
+	jsonCompact   bool
+	exemplars     bool
+	contentionCSV string
+	scatterCSV    string
+	chromeTrace   string
+	assertions    string
+	breakdownMin  float64
+	timeUnit      output.TimeUnit
+	saveRun       string
+	historyFile   string
+	sqlite        string
+	dumpSummary   bool
+}
+
+func runAnalysis(traceFile string, cfg analysisConfig, out analyzeOutput) (*model.Summary, bool) {
+	summary, goroutines, err := parseAndAnalyze(traceFile, cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		return false
+		return nil, false
 	}
 
-	var formatter interface {
-		FormatSummary(*model.Summary) error
+	if out.dumpSummary {
+		dumpGo(os.Stderr, summary)
 	}
-	if jsonFormat {
-		formatter = output.NewJSONFormatter(os.Stdout)
-	} else {
-		formatter = output.NewFormatter(os.Stdout)
+
+	if out.contentionCSV != "" {
+		if err := writeFile(out.contentionCSV, func(w io.Writer) error {
+			return output.WriteContentionCSV(w, analyzer.ContentionTimeline(goroutines))
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing contention CSV: %v\n", err)
+			return summary, false
+		}
 	}
 
-	if err := formatter.FormatSummary(summary); err != nil {
-		fmt.Fprintf(os.Stderr, "Error formatting summary: %v\n", err)
-		return false
+	if out.scatterCSV != "" {
+		if err := writeFile(out.scatterCSV, func(w io.Writer) error {
+			return output.WriteScatterCSV(w, goroutines)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing scatter CSV: %v\n", err)
+			return summary, false
+		}
 	}
 
-	return !summary.HasPerformanceIssues
+	if out.chromeTrace != "" {
+		if err := writeFile(out.chromeTrace, func(w io.Writer) error {
+			return output.NewChromeTraceFormatter(w).FormatTimeline(goroutines, summary.TraceStart)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing Chrome trace: %v\n", err)
+			return summary, false
+		}
+	}
+
+	if out.assertions != "" {
+		if err := writeFile(out.assertions, func(w io.Writer) error {
+			return output.WriteAssertions(w, analyzer.Assertions(summary, goroutines))
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing assertions: %v\n", err)
+			return summary, false
+		}
+	}
+
+	if out.saveRun != "" {
+		if err := saveRun(out.historyFile, out.saveRun, traceFile, summary); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving run: %v\n", err)
+			return summary, false
+		}
+	}
+
+	if out.sqlite != "" {
+		if _, err := output.SaveSQLite(out.sqlite, traceFile, summary, goroutines); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing SQLite: %v\n", err)
+			return summary, false
+		}
+	}
+
+	if out.jsonOut != "" {
+		if err := writeFile(out.jsonOut, func(w io.Writer) error {
+			return out.jsonFormatter(w).FormatSummary(summary)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing JSON report: %v\n", err)
+			return summary, false
+		}
+	}
+
+	if out.format != "" {
+		if err := writeReport(os.Stdout, out, summary, goroutines); err != nil {
+			fmt.Fprintf(os.Stderr, "Error formatting %s output: %v\n", out.format, err)
+			return summary, false
+		}
+	}
+
+	return summary, !summary.HasPerformanceIssues
+}
+
+// analyzeFormats are the formats analyze can emit: every report format plus
+// the exports, which render the whole analysis
+func analyzeFormats() []string {
+	return append(output.Formats(), output.ExportFormats()...)
+}
+
+// selectFormats validates a comma-separated --format list and returns the one
+// format for stdout. JSON goes to jsonOut when set, so at most one other
+// format may remain.
+func selectFormats(list, jsonOut string) (string, error) {
+	var stdout []string
+	for _, f := range strings.Split(list, ",") {
+		f = strings.TrimSpace(f)
+		if !slices.Contains(analyzeFormats(), f) {
+			return "", fmt.Errorf("unknown format %q (want %s)", f, strings.Join(analyzeFormats(), ", "))
+		}
+		if f == "json" && jsonOut != "" {
+			continue
+		}
+		if !slices.Contains(stdout, f) {
+			stdout = append(stdout, f)
+		}
+	}
+	switch len(stdout) {
+	case 0:
+		return "", nil
+	case 1:
+		return stdout[0], nil
+	default:
+		return "", fmt.Errorf("only one format can be written to stdout, got %s (JSON can go to a file with --json-out)", strings.Join(stdout, ","))
+	}
+}
+
+// jsonFormatter returns a JSON formatter on w honoring --json-compact
+func (out analyzeOutput) jsonFormatter(w io.Writer) *output.JSONFormatter {
+	j := output.NewJSONFormatter(w)
+	j.SetCompact(out.jsonCompact)
+	return j
+}
+
+// writeReport renders the analysis in out.format
+func writeReport(w io.Writer, out analyzeOutput, summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo) error {
+	f, err := output.New(out.format, w, output.Options{
+		TimeUnit:        out.timeUnit,
+		BreakdownMinPct: out.breakdownMin,
+		Compact:         out.jsonCompact,
+		Exemplars:       out.exemplars,
+	})
+	if err != nil {
+		return err
+	}
+	if gs, ok := f.(output.GoroutineSetter); ok {
+		gs.SetGoroutines(goroutines)
+	}
+	return f.FormatSummary(summary)
+}
+
+// writeFile creates path and hands it to write, reporting close errors
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"runtime/trace"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// recordTrace traces a goroutine that alternates sleeping and spinning for
+// about 60ms and returns the trace file's path
+func recordTrace(t *testing.T) string {
+	t.Helper()
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	path := filepath.Join(t.TempDir(), "trace.out")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := trace.Start(f); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for end := time.Now().Add(60 * time.Millisecond); time.Now().Before(end); {
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	<-done
+	trace.Stop()
+	return path
+}
+
+// exportCSV runs the analysis with cfg and returns the csv export's rows
+func exportCSV(t *testing.T, path string, cfg analysisConfig) [][]string {
+	t.Helper()
+	summary, goroutines, err := parseAndAnalyze(path, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := writeReport(&buf, analyzeOutput{format: "csv"}, summary, goroutines); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows[1:]
+}
+
+// TestExportsHonorFilters checks the exporters see the goroutines the
+// analysis covered, not everything in the trace
+func TestExportsHonorFilters(t *testing.T) {
+	path := recordTrace(t)
+
+	t.Run("exclude-main", func(t *testing.T) {
+		for _, row := range exportCSV(t, path, analysisConfig{excludeMain: true}) {
+			if row[0] == "1" {
+				t.Errorf("main goroutine exported with --exclude-main: %v", row)
+			}
+		}
+	})
+
+	t.Run("window", func(t *testing.T) {
+		since, until := 10*time.Millisecond, 20*time.Millisecond
+		for _, row := range exportCSV(t, path, analysisConfig{since: since, until: until}) {
+			ns, err := strconv.ParseInt(row[2], 10, 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if blocked := time.Duration(ns); blocked > until-since {
+				t.Errorf("goroutine #%s exported with %s blocked in a %s window", row[0], blocked, until-since)
+			}
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// mapping is one "key: value" line of a mapping file
+type mapping struct {
+	key, value string
+	line       int
+}
+
+// readMappingFile reads "key: value" lines, skipping blanks and # comments.
+// Values never contain colons, so the last one separates the pair and keys
+// may be quoted.
+func readMappingFile(path string) ([]mapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var mappings []mapping
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndex(line, ":")
+		if i < 0 {
+			return nil, fmt.Errorf("%s:%d: expected \"key: value\"", path, n)
+		}
+		key := yamlScalar(strings.TrimSpace(line[:i]))
+		value := yamlScalar(strings.TrimSpace(line[i+1:]))
+		if key == "" || value == "" {
+			return nil, fmt.Errorf("%s:%d: expected \"key: value\"", path, n)
+		}
+		mappings = append(mappings, mapping{key: key, value: value, line: n})
+	}
+	return mappings, scanner.Err()
+}
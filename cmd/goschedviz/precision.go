@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// minTrustedTicks is how many clock ticks a block must span before the
+// one-tick uncertainty at each end is under ~20% of its duration
+const minTrustedTicks = 10
+
+// precisionWarnings explains where trace timestamps carry less precision than
+// the durations derived from them suggest
+func precisionWarnings(p model.TimestampPrecision, goroutines map[uint64]*model.GoroutineInfo) []string {
+	var warnings []string
+	if p.Resolution > 1 {
+		threshold := minTrustedTicks * p.Resolution
+		short := 0
+		for _, g := range goroutines {
+			for _, ev := range g.BlockingEvents {
+				if ev.Duration < threshold {
+					short++
+				}
+			}
+		}
+		if short > 0 {
+			warnings = append(warnings, fmt.Sprintf("the trace clock ticks every %s, so %d blocking events shorter than %s are only accurate to ±%s",
+				p.Resolution, short, threshold, p.Resolution))
+		}
+	}
+	if p.Adjusted > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d events across %d generations were nudged forward at batch boundaries where threads' clocks disagreed; intervals around them may be off by up to a tick",
+			p.Adjusted, p.Generations))
+	}
+	return warnings
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// progressMinSize is the smallest trace worth a progress line; smaller
+	// ones parse before it would be readable
+	progressMinSize = 32 << 20
+	// progressInterval is how often the progress line is redrawn
+	progressInterval = 250 * time.Millisecond
+	// rateSmoothing weighs the latest events-per-second sample against the
+	// rolling rate, so the ETA follows throughput changes without jumping
+	rateSmoothing = 0.3
+)
+
+// countingReader counts the bytes read through it; the count may be read
+// from another goroutine
+type countingReader struct {
+	r    io.Reader
+	read atomic.Int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+// parseProgress draws "42% — ~8s remaining" on stderr while a trace parses.
+// The share of the file read so far extrapolates the total event count, and a
+// rolling events-per-second rate turns the events left into time left.
+type parseProgress struct {
+	name  string
+	size  int64
+	bytes *countingReader
+
+	start      time.Time
+	last       time.Time
+	lastEvents int64
+	rate       float64
+	drawn      bool
+}
+
+// newParseProgress wraps f for progress reporting; it returns nil if the
+// trace is too small or stderr isn't a terminal
+func newParseProgress(f *os.File) *parseProgress {
+	stat, err := f.Stat()
+	if err != nil || stat.Size() < progressMinSize || !stderrIsTerminal() {
+		return nil
+	}
+	now := time.Now()
+	return &parseProgress{
+		name:  filepath.Base(f.Name()),
+		size:  stat.Size(),
+		bytes: &countingReader{r: f},
+		start: now,
+		last:  now,
+	}
+}
+
+// update takes the number of events parsed so far and redraws the line if
+// progressInterval has passed
+func (p *parseProgress) update(events int64) {
+	now := time.Now()
+	elapsed := now.Sub(p.last)
+	if elapsed < progressInterval {
+		return
+	}
+	sample := float64(events-p.lastEvents) / elapsed.Seconds()
+	if p.rate == 0 {
+		p.rate = sample
+	} else {
+		p.rate = rateSmoothing*sample + (1-rateSmoothing)*p.rate
+	}
+	p.last, p.lastEvents = now, events
+
+	read := p.bytes.read.Load()
+	if read == 0 || p.rate == 0 {
+		return
+	}
+	frac := min(float64(read)/float64(p.size), 1)
+	total := float64(events) / frac
+	remaining := time.Duration((total - float64(events)) / p.rate * float64(time.Second))
+
+	fmt.Fprintf(os.Stderr, "\rParsing %s: %.0f%% — %s events/s — ~%s remaining\033[K",
+		p.name, frac*100, formatRate(p.rate), remaining.Round(time.Second))
+	p.drawn = true
+}
+
+// done clears the progress line
+func (p *parseProgress) done() {
+	if p.drawn {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
+
+// formatRate shortens an events-per-second rate, e.g. 1.2M
+func formatRate(r float64) string {
+	switch {
+	case r >= 1e6:
+		return fmt.Sprintf("%.1fM", r/1e6)
+	case r >= 1e3:
+		return fmt.Sprintf("%.1fk", r/1e3)
+	}
+	return fmt.Sprintf("%.0f", r)
+}
+
+// stderrIsTerminal reports whether stderr is an interactive terminal
+func stderrIsTerminal() bool {
+	stat, err := os.Stderr.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+	"github.com/goschedviz/goschedviz/internal/traceparser"
+)
+
+// loadReasonMap reads a reason override file: one "substring: reason" pair
+// per line, tried top to bottom before the built-in matching, e.g.
+//
+//	# custom runtime waits
+//	"wait for shard": mutex
+//	rpc reply: network
+func loadReasonMap(path string) ([]traceparser.ReasonOverride, error) {
+	mappings, err := readMappingFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make([]traceparser.ReasonOverride, 0, len(mappings))
+	for _, m := range mappings {
+		reasons, err := model.ParseBlockingReasons(m.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, m.line, err)
+		}
+		if len(reasons) != 1 {
+			return nil, fmt.Errorf("%s:%d: want exactly one reason, got %q", path, m.line, m.value)
+		}
+		overrides = append(overrides, traceparser.ReasonOverride{Substring: m.key, Reason: reasons[0]})
+	}
+	return overrides, nil
+}
+
+// reportUnmappedReasons prints the wait reasons that fell through to "none",
+// so they can be added to a --reason-map file
+func reportUnmappedReasons(unmapped map[string]int) {
+	if len(unmapped) == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Unmapped wait reasons (classified as none; map them with --reason-map):\n")
+	for _, reason := range slices.Sorted(maps.Keys(unmapped)) {
+		fmt.Fprintf(os.Stderr, "  %-40q %d\n", reason, unmapped[reason])
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// defaultWatchInterval is how often a watched trace file is polled
+const defaultWatchInterval = 500 * time.Millisecond
+
+// clock abstracts waiting so the watch loop can be driven deterministically
+type clock interface {
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock waits on the system clock
+type realClock struct{}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// fileWatcher re-runs an action whenever a file's modification time changes;
+// a replacement can be older than the file it replaced (e.g. cp -p)
+type fileWatcher struct {
+	clock    clock
+	interval time.Duration
+	out      io.Writer
+	stat     func(string) (os.FileInfo, error)
+
+	// clear wipes the screen before each refresh
+	clear bool
+}
+
+// newFileWatcher creates a watcher polling every interval on c
+func newFileWatcher(c clock, interval time.Duration) *fileWatcher {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	return &fileWatcher{
+		clock:    c,
+		interval: interval,
+		out:      os.Stdout,
+		stat:     os.Stat,
+		clear:    true,
+	}
+}
+
+// watch polls path until ctx is cancelled, running action on every change,
+// and returns how many times it ran. While the file is missing it backs off
+// to twice the interval.
+func (w *fileWatcher) watch(ctx context.Context, path string, action func() bool) int {
+	lastMod := time.Time{}
+	refreshes := 0
+
+	fmt.Fprintf(w.out, "👀 Watching %s for changes... (Ctrl+C to stop)\n", path)
+
+	for {
+		wait := w.interval
+		stat, err := w.stat(path)
+		if err != nil {
+			wait = 2 * w.interval
+		} else if !stat.ModTime().Equal(lastMod) {
+			// Clear screen for a clean update
+			if w.clear {
+				fmt.Fprint(w.out, "\033[H\033[2J")
+			}
+			action()
+			refreshes++
+			lastMod = stat.ModTime()
+			fmt.Fprintf(w.out, "\n👀 Last updated: %s. Watching for changes...\n", lastMod.Format("15:04:05"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return refreshes
+		case <-w.clock.After(wait):
+		}
+	}
+}
+
+// watchFile re-runs action whenever the file at path changes until
+// interrupted, then prints how many refreshes ran and the latest verdict.
+// With stream set, stdout carries nothing but the action's output (e.g.
+// NDJSON for a pipe): status lines go to stderr and the screen isn't cleared.
+func watchFile(path string, interval time.Duration, stream bool, action func() bool, lastVerdict func() string) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := newFileWatcher(realClock{}, interval)
+	if stream {
+		w.out = os.Stderr
+		w.clear = false
+	}
+
+	// The action doesn't see ctx, so a parse in progress finishes before the
+	// loop notices; restoring the default handler lets a second Ctrl+C end it
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	refreshes := w.watch(ctx, path, action)
+
+	fmt.Fprintf(w.out, "\n⏹  Stopped watching %s after %d refresh(es).\n", path, refreshes)
+	if v := lastVerdict(); v != "" {
+		fmt.Fprintln(w.out, v)
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"testing"
+	"time"
+)
+
+// fakeClock hands each wait to the test, which decides when it ends
+type fakeClock struct {
+	waits chan time.Duration
+	fire  chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{waits: make(chan time.Duration), fire: make(chan time.Time)}
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.waits <- d
+	return c.fire
+}
+
+// fakeFile is a file's modification time as the watcher stats it; a zero
+// time means the file doesn't exist
+type fakeFile struct {
+	os.FileInfo
+	mod time.Time
+}
+
+func (f fakeFile) ModTime() time.Time { return f.mod }
+
+// watchHarness runs a watcher on a fake clock and file in the background
+type watchHarness struct {
+	t     *testing.T
+	clock *fakeClock
+	mod   time.Time
+	runs  int
+	stop  context.CancelFunc
+	done  chan int
+}
+
+func startWatch(t *testing.T, mod time.Time) *watchHarness {
+	h := &watchHarness{t: t, clock: newFakeClock(), mod: mod, done: make(chan int)}
+	w := newFileWatcher(h.clock, time.Second)
+	w.out = io.Discard
+	w.clear = false
+	w.stat = func(string) (os.FileInfo, error) {
+		if h.mod.IsZero() {
+			return nil, fs.ErrNotExist
+		}
+		return fakeFile{mod: h.mod}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stop = cancel
+	go func() {
+		h.done <- w.watch(ctx, "trace.out", func() bool {
+			h.runs++
+			return true
+		})
+	}()
+	return h
+}
+
+// poll waits for the watcher to start waiting, checks how long it asked to
+// wait, applies change while it is parked, then lets the wait end
+func (h *watchHarness) poll(want time.Duration, change func()) {
+	h.t.Helper()
+	if d := <-h.clock.waits; d != want {
+		h.t.Errorf("watcher waited %s, want %s", d, want)
+	}
+	if change != nil {
+		change()
+	}
+	h.clock.fire <- time.Time{}
+}
+
+// finish stops the watcher once it is waiting again and returns its count
+func (h *watchHarness) finish() int {
+	<-h.clock.waits
+	h.stop()
+	return <-h.done
+}
+
+func TestWatchUnchangedFileRunsOnce(t *testing.T) {
+	h := startWatch(t, time.Unix(100, 0))
+	for range 3 {
+		h.poll(time.Second, nil)
+	}
+	if n := h.finish(); n != 1 || h.runs != 1 {
+		t.Errorf("got %d refreshes (%d runs), want only the initial one", n, h.runs)
+	}
+}
+
+func TestWatchDebouncesWritesWithinAnInterval(t *testing.T) {
+	h := startWatch(t, time.Unix(100, 0))
+	// Several writes land before the next poll; they refresh once
+	h.poll(time.Second, func() {
+		for i := range 5 {
+			h.mod = time.Unix(101+int64(i), 0)
+		}
+	})
+	h.poll(time.Second, nil)
+	if n := h.finish(); n != 2 {
+		t.Errorf("got %d refreshes, want the initial one and one for the writes", n)
+	}
+}
+
+func TestWatchReplacedFile(t *testing.T) {
+	h := startWatch(t, time.Unix(100, 0))
+	// While the file is gone the watcher backs off and doesn't refresh
+	h.poll(time.Second, func() { h.mod = time.Time{} })
+	h.poll(2*time.Second, nil)
+	h.poll(2*time.Second, func() { h.mod = time.Unix(200, 0) })
+	h.poll(time.Second, nil)
+	// A replacement that keeps an older modification time still refreshes
+	h.poll(time.Second, func() { h.mod = time.Unix(50, 0) })
+	if n := h.finish(); n != 3 {
+		t.Errorf("got %d refreshes, want the initial one and one for each new file", n)
+	}
+}
@@ -1,53 +1,442 @@
 package analyzer
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/goschedviz/goschedviz/internal/model"
 )
 
+// mainGoroutineID is the goroutine running main.main
+const mainGoroutineID = 1
+
+// maxWorstStalls is how many goroutines the worst-stall ranking keeps
+const maxWorstStalls = 5
+
+// A goroutine migrates excessively when at least migrationRate of its runs,
+// over at least minMigrationSchedules of them, resume on a different M
+const (
+	migrationRate         = 0.5
+	minMigrationSchedules = 20
+	maxMigrationHeavy     = 5
+)
+
+// The scheduler thrashes when, over at least churnMinSwitches switches, each P
+// switches goroutines more than churnPerProcSecond times a second and the
+// average run slice is under churnMaxRunSlice
+const (
+	churnMinSwitches   = 10000
+	churnPerProcSecond = 20000
+	churnMaxRunSlice   = 20 * time.Microsecond
+)
+
 // Analyzer detects performance bottlenecks and patterns
 type Analyzer struct {
-	goroutines map[uint64]*model.GoroutineInfo
-	summary    *model.Summary
+	goroutines   map[uint64]*model.GoroutineInfo
+	summary      *model.Summary
+	gomaxprocs   int
+	ignored      map[model.BlockingReason]bool
+	longestN     int
+	topN         int
+	chains       bool
+	cycles       bool
+	skipInit     time.Duration
+	since        time.Duration
+	until        time.Duration
+	noiseFloor   time.Duration
+	skipMain     bool
+	skipIdle     bool
+	focus        map[model.BlockingReason]bool
+	gc           *model.GCActivity
+	busyProcs    []model.ProcSample
+	peakRunnable int
+
+	concurrencyRatio float64
+
+	// Observed trace span, derived from goroutine timestamps
+	traceStart time.Duration
+	traceEnd   time.Duration
 }
 
 // NewAnalyzer creates a performance analyzer
 func NewAnalyzer(goroutines map[uint64]*model.GoroutineInfo) *Analyzer {
 	return &Analyzer{
-		goroutines: goroutines,
-		summary:    &model.Summary{},
+		goroutines:       goroutines,
+		summary:          &model.Summary{},
+		topN:             DefaultTopN,
+		concurrencyRatio: DefaultConcurrencyRatio,
+	}
+}
+
+// DefaultTopN is how many of the most blocked goroutines the summary lists
+const DefaultTopN = 10
+
+// SetGOMAXPROCS provides the trace's GOMAXPROCS for parallelism-aware metrics
+func (a *Analyzer) SetGOMAXPROCS(n int) {
+	a.gomaxprocs = n
+}
+
+// SetGCActivity provides the GC cycles and pauses counted from the trace, so
+// the summary can report GC frequency
+func (a *Analyzer) SetGCActivity(gc model.GCActivity) {
+	a.gc = &gc
+}
+
+// SetBusyProcs provides the trace's busy-P timeline, which tells CPU
+// saturation apart from scheduling anomalies in long runnable waits
+func (a *Analyzer) SetBusyProcs(samples []model.ProcSample) {
+	a.busyProcs = samples
+}
+
+// SetPeakRunnable provides the most goroutines the trace had runnable at
+// once, which starvation detection compares against GOMAXPROCS
+func (a *Analyzer) SetPeakRunnable(n int) {
+	a.peakRunnable = n
+}
+
+// SetIgnoredReasons excludes the given reasons (e.g. deliberate sleeps) from
+// blocked-time totals, the breakdown, and top-blocked ranking
+func (a *Analyzer) SetIgnoredReasons(reasons []model.BlockingReason) {
+	a.ignored = make(map[model.BlockingReason]bool, len(reasons))
+	for _, r := range reasons {
+		a.ignored[r] = true
+	}
+}
+
+// SetLongestBlocks requests the n longest individual blocking events
+func (a *Analyzer) SetLongestBlocks(n int) {
+	a.longestN = n
+}
+
+// SetTopN lists the n most blocked goroutines in the summary; 0 lists every
+// goroutine that blocked
+func (a *Analyzer) SetTopN(n int) {
+	a.topN = n
+}
+
+// SetBlockingChains enables reconstruction of causal blocking chains
+func (a *Analyzer) SetBlockingChains(enabled bool) {
+	a.chains = enabled
+}
+
+// SetReasonCycles enables detection of two-reason blocking loops; the parser
+// must record reason sequences
+func (a *Analyzer) SetReasonCycles(enabled bool) {
+	a.cycles = enabled
+}
+
+// SetSkipInit excludes blocking during the first d of the trace (program
+// startup) from aggregation; blocks straddling the cutoff are clipped
+func (a *Analyzer) SetSkipInit(d time.Duration) {
+	a.skipInit = d
+}
+
+// SetWindow restricts aggregation to blocking between since and until,
+// offsets from the start of the trace (until 0 means the end); blocks
+// straddling either edge are clipped to the window
+func (a *Analyzer) SetWindow(since, until time.Duration) {
+	a.since, a.until = since, until
+}
+
+// SetNoiseFloor discards blocking events shorter than d, which are usually
+// scheduler noise, from counts and aggregates
+func (a *Analyzer) SetNoiseFloor(d time.Duration) {
+	a.noiseFloor = d
+}
+
+// SetExcludeMain drops the main goroutine from all aggregates; its deliberate
+// wait for workers or shutdown otherwise tops the blocked ranking
+func (a *Analyzer) SetExcludeMain(exclude bool) {
+	a.skipMain = exclude
+}
+
+// SetExcludeIdle drops goroutines that never ran during the trace, such as
+// listeners and pool workers parked for the whole capture
+func (a *Analyzer) SetExcludeIdle(exclude bool) {
+	a.skipIdle = exclude
+}
+
+// SetFocusReasons restricts the analysis to goroutines whose blocking is
+// dominated by one of reasons
+func (a *Analyzer) SetFocusReasons(reasons []model.BlockingReason) {
+	a.focus = make(map[model.BlockingReason]bool, len(reasons))
+	for _, r := range reasons {
+		a.focus[r] = true
 	}
 }
 
+// SetConcurrencyRatio sets how many times the goroutines running or runnable
+// at once the goroutine count must reach before a concurrency cap is recommended; 0
+// disables the recommendation
+func (a *Analyzer) SetConcurrencyRatio(ratio float64) {
+	a.concurrencyRatio = ratio
+}
+
 // Analyze performs comprehensive bottleneck detection
 func (a *Analyzer) Analyze() *model.Summary {
+	a.computeTraceBounds()
+	if a.skipMain {
+		a.goroutines = a.withoutMain()
+	}
+	if a.skipIdle {
+		a.goroutines = a.withoutIdle()
+	}
+	if len(a.ignored) > 0 || a.windowed() || a.noiseFloor > 0 {
+		a.goroutines = a.filterGoroutines()
+	}
+	if len(a.focus) > 0 {
+		a.goroutines = a.focusedGoroutines()
+	}
+
 	a.summary.TotalGoroutines = len(a.goroutines)
+	a.summary.AliveAtEnd = 0
+	for _, g := range a.goroutines {
+		if g.TerminatedAt == 0 {
+			a.summary.AliveAtEnd++
+		}
+	}
+	a.summary.NoiseFloor = a.noiseFloor
+	if a.since > 0 || a.until > 0 {
+		a.summary.Window = &model.TimeWindow{Since: a.since, Until: a.until}
+	}
 	a.summary.PeakGoroutines = len(a.goroutines)
 
 	a.aggregateBlockingStats()
+	a.computeIdealWallTime()
+	a.computeGCFrequency()
+	a.computeGoroutineTimeline()
+	a.computeBlockingTiming()
+	a.computeBlockedOverlap()
+	a.detectOscillation()
+	a.detectCliffs()
+	a.detectGrowth()
+	a.detectOversubscription()
+	a.detectConcurrencyCap()
 	a.findTopBlocked()
+	a.summary.BlockingGini = BlockingGini(a.goroutines)
+	a.findWorstStalls()
+	a.findMigrationHeavy()
+	a.measureChurn()
+	a.classifyStarvation()
+	a.findLongestBlocks()
+	a.analyzeLockContention()
+	a.detectChannelImbalance()
+	if a.cycles {
+		a.findReasonCycles()
+	}
+	if a.chains {
+		a.findBlockingChains()
+	}
+	a.findCriticalPath()
 	a.detectPerformanceIssues()
 
 	return a.summary
 }
 
+// withoutMain returns the goroutine set minus the main goroutine, leaving the
+// caller's map untouched
+func (a *Analyzer) withoutMain() map[uint64]*model.GoroutineInfo {
+	rest := make(map[uint64]*model.GoroutineInfo, len(a.goroutines))
+	for gid, g := range a.goroutines {
+		if gid != mainGoroutineID {
+			rest[gid] = g
+		}
+	}
+	return rest
+}
+
+// withoutIdle returns the goroutines that ran at some point in the trace,
+// leaving the caller's map untouched
+func (a *Analyzer) withoutIdle() map[uint64]*model.GoroutineInfo {
+	active := make(map[uint64]*model.GoroutineInfo, len(a.goroutines))
+	for gid, g := range a.goroutines {
+		if g.TotalRuntime > 0 {
+			active[gid] = g
+		}
+	}
+	return active
+}
+
+// Goroutines returns the goroutines the last Analyze covered, after the
+// exclusions and filters; the map passed to NewAnalyzer is never modified
+func (a *Analyzer) Goroutines() map[uint64]*model.GoroutineInfo {
+	return a.goroutines
+}
+
+// focusedGoroutines returns the goroutines whose dominant blocking reason is
+// one of the focus reasons; goroutines that never blocked are dropped
+func (a *Analyzer) focusedGoroutines() map[uint64]*model.GoroutineInfo {
+	focused := make(map[uint64]*model.GoroutineInfo)
+	for gid, g := range a.goroutines {
+		if g.TotalBlocked > 0 && a.focus[a.GetBlockingReason(g)] {
+			focused[gid] = g
+		}
+	}
+	return focused
+}
+
+// windowed reports whether blocking is clipped to a time window, by
+// --skip-init or --since/--until
+func (a *Analyzer) windowed() bool {
+	return a.skipInit > 0 || a.since > 0 || a.until > 0
+}
+
+// window returns the absolute bounds blocking is clipped to
+func (a *Analyzer) window() (lo, hi time.Duration) {
+	lo = a.traceStart + max(a.skipInit, a.since)
+	hi = a.traceEnd
+	if a.until > 0 {
+		hi = min(hi, a.traceStart+a.until)
+	}
+	return lo, hi
+}
+
+// filterGoroutines returns copies of the goroutines with ignored reasons,
+// blocking outside the time window and sub-noise-floor events stripped, so
+// totals and percentages only reflect what remains
+func (a *Analyzer) filterGoroutines() map[uint64]*model.GoroutineInfo {
+	lo, hi := a.window()
+	windowed := a.windowed()
+
+	filtered := make(map[uint64]*model.GoroutineInfo, len(a.goroutines))
+	for gid, g := range a.goroutines {
+		c := *g
+		c.TotalBlocked = 0
+		c.BlockingByReason = make(map[model.BlockingReason]time.Duration, len(g.BlockingByReason))
+		c.BlockingEvents = make([]model.BlockingEvent, 0, len(g.BlockingEvents))
+		c.LongestBlock = model.BlockingEvent{}
+		noise := make(map[model.BlockingReason]time.Duration)
+
+		for _, ev := range g.BlockingEvents {
+			if a.ignored[ev.Reason] {
+				continue
+			}
+			if windowed {
+				if ev.EndTime <= lo || ev.StartTime >= hi {
+					continue
+				}
+				ev.StartTime, ev.EndTime = max(ev.StartTime, lo), min(ev.EndTime, hi)
+				ev.Duration = ev.EndTime - ev.StartTime
+			}
+			if ev.Duration < a.noiseFloor {
+				noise[ev.Reason] += ev.Duration
+				a.summary.NoiseEvents++
+				a.summary.NoiseTime += ev.Duration
+				continue
+			}
+			if windowed {
+				// Time-clipped totals must be rebuilt from the events
+				c.BlockingByReason[ev.Reason] += ev.Duration
+				c.TotalBlocked += ev.Duration
+			}
+			c.BlockingEvents = append(c.BlockingEvents, ev)
+			if ev.Duration > c.LongestBlock.Duration {
+				c.LongestBlock = ev
+			}
+		}
+		if !windowed {
+			for reason, d := range g.BlockingByReason {
+				if d -= noise[reason]; d > 0 && !a.ignored[reason] {
+					c.BlockingByReason[reason] = d
+					c.TotalBlocked += d
+				}
+			}
+		}
+		if len(a.ignored) > 0 && g.DroppedEvents > 0 {
+			c.DroppedEvents = 0
+			c.DroppedByReason = make(map[model.BlockingReason]int, len(g.DroppedByReason))
+			for reason, n := range g.DroppedByReason {
+				if !a.ignored[reason] {
+					c.DroppedByReason[reason] = n
+					c.DroppedEvents += n
+				}
+			}
+			if a.ignored[model.BlockSelect] {
+				c.DroppedSelects = nil
+			}
+		}
+		filtered[gid] = &c
+	}
+	return filtered
+}
+
 // aggregateBlockingStats computes blocking breakdown across all goroutines
 func (a *Analyzer) aggregateBlockingStats() {
 	a.summary.BlockingBreakdown = make(map[model.BlockingReason]time.Duration)
 	a.summary.BlockingPercent = make(map[model.BlockingReason]float64)
+	a.summary.BlockingEventCount = make(map[model.BlockingReason]int)
+	a.summary.GoroutinesByReason = make(map[model.BlockingReason]int)
+	a.summary.SelectOutcomes = make(map[model.SelectCase]int)
 
+	type reasonSite struct {
+		reason model.BlockingReason
+		site   model.SiteID
+	}
+	siteTime := make(map[reasonSite]time.Duration)
 	var totalBlocked time.Duration
 
 	for _, g := range a.goroutines {
 		a.summary.TotalBlockedTime += g.TotalBlocked
 		a.summary.TotalRuntime += g.TotalRuntime
+		a.summary.TotalRunnable += g.TotalRunnable
 		totalBlocked += g.TotalBlocked
+		if g.Finalizer {
+			a.summary.FinalizerRuntime += g.TotalRuntime
+		}
+		// A goroutine still parked in a wait open since the trace began has
+		// no finished block but did block
+		if g.TotalBlocked == 0 && g.TotalRuntime > 0 && g.PendingBlock == nil {
+			a.summary.NeverBlocked++
+			a.summary.NeverBlockedRuntime += g.TotalRuntime
+		}
 
 		for reason, duration := range g.BlockingByReason {
 			a.summary.BlockingBreakdown[reason] += duration
+			if duration > 0 {
+				a.summary.GoroutinesByReason[reason]++
+			}
+		}
+		// Events dropped by the parser's retention cap still happened
+		for reason, n := range g.DroppedByReason {
+			a.summary.BlockingEventCount[reason] += n
+		}
+		for c, n := range g.DroppedSelects {
+			a.summary.SelectOutcomes[c] += n
+		}
+		for _, ev := range g.BlockingEvents {
+			a.summary.BlockingEventCount[ev.Reason]++
+			if ev.Reason == model.BlockSelect {
+				a.summary.SelectOutcomes[ev.SelectCase]++
+			}
+			if ev.Frames != 0 {
+				siteTime[reasonSite{ev.Reason, ev.Frames}] += ev.Duration
+			}
+		}
+	}
+
+	// Several operations can share a call site, so sites are summed by name
+	byName := make(map[model.BlockingReason]map[string]time.Duration)
+	for k, d := range siteTime {
+		name := k.site.Site()
+		if name == "" {
+			continue
+		}
+		if byName[k.reason] == nil {
+			byName[k.reason] = make(map[string]time.Duration)
+		}
+		byName[k.reason][name] += d
+	}
+	a.summary.ReasonSites = make(map[model.BlockingReason]string, len(byName))
+	for reason, sites := range byName {
+		var best string
+		for name, d := range sites {
+			if best == "" || d > sites[best] || (d == sites[best] && name < best) {
+				best = name
+			}
 		}
+		a.summary.ReasonSites[reason] = best
 	}
 
 	// Calculate percentages
@@ -59,6 +448,50 @@ func (a *Analyzer) aggregateBlockingStats() {
 	}
 }
 
+// computeTraceBounds derives the observed trace span from goroutine timestamps
+func (a *Analyzer) computeTraceBounds() {
+	var start, end time.Duration
+	first := true
+	for _, g := range a.goroutines {
+		if first || g.CreatedAt < start {
+			start = g.CreatedAt
+		}
+		if first || g.LastStateChange > end {
+			end = g.LastStateChange
+		}
+		first = false
+	}
+	a.traceStart, a.traceEnd = start, end
+	a.summary.TraceStart = start
+	a.summary.WallTime = end - start
+}
+
+// computeIdealWallTime estimates the wall time the workload would need with
+// zero blocking and compares it to the observed trace span
+func (a *Analyzer) computeIdealWallTime() {
+	a.summary.GOMAXPROCS = a.gomaxprocs
+	a.summary.PeakRunnable = a.peakRunnable
+	if a.gomaxprocs > 0 {
+		a.summary.IdealWallTime = a.summary.TotalRuntime / time.Duration(a.gomaxprocs)
+	}
+}
+
+// computeGCFrequency turns the raw GC counts into a rate over the trace span
+// and an average pause
+func (a *Analyzer) computeGCFrequency() {
+	if a.gc == nil {
+		return
+	}
+	gc := *a.gc
+	if a.summary.WallTime > 0 {
+		gc.PerSecond = float64(gc.Cycles) / a.summary.WallTime.Seconds()
+	}
+	if gc.Pauses > 0 {
+		gc.AvgPause = gc.TotalPause / time.Duration(gc.Pauses)
+	}
+	a.summary.GC = &gc
+}
+
 // findTopBlocked identifies goroutines with highest blocking time
 func (a *Analyzer) findTopBlocked() {
 	type blockedItem struct {
@@ -74,11 +507,14 @@ func (a *Analyzer) findTopBlocked() {
 	}
 
 	sort.Slice(items, func(i, j int) bool {
-		return items[i].total > items[j].total
+		if items[i].total != items[j].total {
+			return items[i].total > items[j].total
+		}
+		return items[i].g.ID < items[j].g.ID
 	})
 
-	topN := 10
-	if len(items) < topN {
+	topN := a.topN
+	if topN <= 0 || len(items) < topN {
 		topN = len(items)
 	}
 
@@ -88,53 +524,390 @@ func (a *Analyzer) findTopBlocked() {
 	}
 }
 
+// BlockingGini computes the Gini coefficient of blocked time over goroutines
+// that blocked at all; it is 0 with fewer than two of them
+func BlockingGini(goroutines map[uint64]*model.GoroutineInfo) float64 {
+	var blocked []float64
+	for _, g := range goroutines {
+		if g.TotalBlocked > 0 {
+			blocked = append(blocked, float64(g.TotalBlocked))
+		}
+	}
+	n := len(blocked)
+	if n < 2 {
+		return 0
+	}
+	sort.Float64s(blocked)
+
+	// G = sum((2i - n - 1) * x_i) / (n * sum(x)) over ascending x, 1-based i
+	var weighted, total float64
+	for i, x := range blocked {
+		weighted += float64(2*(i+1)-n-1) * x
+		total += x
+	}
+	return weighted / (float64(n) * total)
+}
+
+// findWorstStalls ranks goroutines by their longest single block; unlike
+// TopBlocked this ignores how many blocks add up to the total
+func (a *Analyzer) findWorstStalls() {
+	var stalled []*model.GoroutineInfo
+	for _, g := range a.goroutines {
+		if g.LongestBlock.Duration > 0 {
+			stalled = append(stalled, g)
+		}
+	}
+
+	sort.Slice(stalled, func(i, j int) bool {
+		if stalled[i].LongestBlock.Duration != stalled[j].LongestBlock.Duration {
+			return stalled[i].LongestBlock.Duration > stalled[j].LongestBlock.Duration
+		}
+		return stalled[i].ID < stalled[j].ID
+	})
+
+	if len(stalled) > maxWorstStalls {
+		stalled = stalled[:maxWorstStalls]
+	}
+	a.summary.WorstStalls = stalled
+}
+
+// findMigrationHeavy ranks goroutines that keep hopping between OS threads
+// and so lose cache locality
+func (a *Analyzer) findMigrationHeavy() {
+	var heavy []*model.GoroutineInfo
+	for _, g := range a.goroutines {
+		a.summary.TotalMigrations += g.Migrations
+		if g.Schedules >= minMigrationSchedules && float64(g.Migrations) >= migrationRate*float64(g.Schedules) {
+			heavy = append(heavy, g)
+		}
+	}
+
+	sort.Slice(heavy, func(i, j int) bool {
+		if heavy[i].Migrations != heavy[j].Migrations {
+			return heavy[i].Migrations > heavy[j].Migrations
+		}
+		return heavy[i].ID < heavy[j].ID
+	})
+
+	if len(heavy) > maxMigrationHeavy {
+		heavy = heavy[:maxMigrationHeavy]
+	}
+	a.summary.MigrationHeavy = heavy
+}
+
+// measureChurn computes the system-wide runnable→running switch rate and the
+// average run slice, and flags thrashing
+func (a *Analyzer) measureChurn() {
+	churn := model.SchedulerChurn{}
+	for _, g := range a.goroutines {
+		churn.Switches += g.Schedules
+	}
+	if churn.Switches == 0 {
+		return
+	}
+	churn.AvgRunSlice = a.summary.TotalRuntime / time.Duration(churn.Switches)
+	if a.summary.WallTime > 0 {
+		churn.PerSecond = float64(churn.Switches) / a.summary.WallTime.Seconds()
+	}
+	if a.gomaxprocs > 0 {
+		churn.PerProcSecond = churn.PerSecond / float64(a.gomaxprocs)
+	}
+	// Without GOMAXPROCS the whole rate is charged to a single P
+	perProc := churn.PerSecond / float64(max(a.gomaxprocs, 1))
+	churn.Thrashing = churn.Switches >= churnMinSwitches &&
+		perProc > churnPerProcSecond &&
+		churn.AvgRunSlice < churnMaxRunSlice
+	a.summary.Churn = &churn
+}
+
+// saturatedShare is the fraction of a long runnable wait during which every P
+// must have been busy for the wait to count as plain CPU saturation
+const saturatedShare = 0.9
+
+// classifyStarvation checks each long runnable wait against the busy-P
+// timeline: waiting while every P runs is saturation, waiting while a P sits
+// idle is something the scheduler shouldn't do
+func (a *Analyzer) classifyStarvation() {
+	if len(a.busyProcs) == 0 {
+		return
+	}
+	// Without GOMAXPROCS the most Ps ever seen running stands in for it
+	procs := a.gomaxprocs
+	if procs <= 0 {
+		for _, s := range a.busyProcs {
+			procs = max(procs, s.Busy)
+		}
+	}
+
+	var rs model.RunnableStarvation
+	for gid, g := range a.goroutines {
+		for _, iv := range g.LongRunnable {
+			wait := iv.End - iv.Start
+			full := a.fullyBusyTime(iv.Start, iv.End, procs)
+			if float64(full) >= saturatedShare*float64(wait) {
+				rs.Saturated++
+				rs.SaturatedTime += wait
+				continue
+			}
+			rs.Anomalous++
+			rs.AnomalousTime += wait
+			if rs.WorstAnomaly == nil || wait > rs.WorstAnomaly.Duration ||
+				(wait == rs.WorstAnomaly.Duration && gid < rs.WorstAnomaly.GoroutineID) {
+				rs.WorstAnomaly = &model.RunnableWait{
+					GoroutineID: gid,
+					Start:       iv.Start - a.traceStart,
+					Duration:    wait,
+					IdleShare:   1 - float64(full)/float64(wait),
+				}
+			}
+		}
+	}
+	if rs.Saturated+rs.Anomalous > 0 {
+		a.summary.RunnableStarvation = &rs
+	}
+}
+
+// fullyBusyTime is how much of [start, end) had at least procs Ps running
+func (a *Analyzer) fullyBusyTime(start, end time.Duration, procs int) time.Duration {
+	samples := a.busyProcs
+	// The sample in force at start is the last one at or before it
+	i := sort.Search(len(samples), func(i int) bool { return samples[i].At > start }) - 1
+	var full time.Duration
+	for at := start; at < end; i++ {
+		busy, next := 0, end
+		if i >= 0 {
+			busy = samples[i].Busy
+		}
+		if i+1 < len(samples) && samples[i+1].At < end {
+			next = samples[i+1].At
+		}
+		if busy >= procs {
+			full += next - at
+		}
+		at = next
+	}
+	return full
+}
+
+// findLongestBlocks collects the single worst stalls across the whole trace
+func (a *Analyzer) findLongestBlocks() {
+	if a.longestN <= 0 {
+		return
+	}
+
+	var blocks []model.GoroutineBlock
+	for gid, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			blocks = append(blocks, model.GoroutineBlock{GoroutineID: gid, Event: ev})
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		if blocks[i].Event.Duration != blocks[j].Event.Duration {
+			return blocks[i].Event.Duration > blocks[j].Event.Duration
+		}
+		return blocks[i].GoroutineID < blocks[j].GoroutineID
+	})
+
+	if len(blocks) > a.longestN {
+		blocks = blocks[:a.longestN]
+	}
+	a.summary.LongestBlocks = blocks
+}
+
 // detectPerformanceIssues identifies suspicious patterns
 func (a *Analyzer) detectPerformanceIssues() {
 	a.summary.Issues = make([]string, 0)
 
 	// Check for excessive channel blocking
-	if pct, ok := a.summary.BlockingPercent[model.BlockChannelRecv]; ok && pct > 40 {
+	if pct, ok := a.summary.BlockingPercent[model.BlockChannelRecv]; ok && pct > maxChannelRecvPct {
 		a.summary.HasPerformanceIssues = true
 		a.summary.Issues = append(a.summary.Issues, "Excessive channel receive blocking (>40%)")
 	}
 
-	if pct, ok := a.summary.BlockingPercent[model.BlockChannelSend]; ok && pct > 40 {
+	if pct, ok := a.summary.BlockingPercent[model.BlockChannelSend]; ok && pct > maxChannelSendPct {
 		a.summary.HasPerformanceIssues = true
 		a.summary.Issues = append(a.summary.Issues, "Excessive channel send blocking (>40%)")
 	}
 
 	// Check for mutex contention
-	if pct, ok := a.summary.BlockingPercent[model.BlockMutexLock]; ok && pct > 30 {
+	if pct, ok := a.summary.BlockingPercent[model.BlockMutexLock]; ok && pct > maxMutexPct {
 		a.summary.HasPerformanceIssues = true
 		a.summary.Issues = append(a.summary.Issues, "High mutex contention (>30%)")
 	}
 
 	// Check for GC pressure
-	if pct, ok := a.summary.BlockingPercent[model.BlockGC]; ok && pct > 15 {
+	if pct, ok := a.summary.BlockingPercent[model.BlockGC]; ok && pct > maxGCPct {
 		a.summary.HasPerformanceIssues = true
 		a.summary.Issues = append(a.summary.Issues, "High GC pressure (>15%)")
 	}
 
 	// Check if single goroutine dominates blocking
 	if len(a.summary.TopBlocked) > 0 {
-		topBlockedPct := float64(a.summary.TopBlocked[0].TotalBlocked) / float64(a.summary.TotalBlockedTime) * 100
-		if topBlockedPct > 50 {
+		if topBlockedPct(a.summary) > maxTopGoroutinePct {
 			a.summary.HasPerformanceIssues = true
 			a.summary.Issues = append(a.summary.Issues, "Single goroutine accounts for >50% of blocking time")
 		}
 	}
 
-	// Check for long runnable periods (starvation detection)
-	for _, g := range a.goroutines {
-		if g.TotalRunnable > 0 && g.TotalRuntime > 0 {
-			runnableRatio := float64(g.TotalRunnable) / float64(g.TotalRunnable+g.TotalRuntime)
-			if runnableRatio > 0.7 {
-				a.summary.HasPerformanceIssues = true
-				a.summary.Issues = append(a.summary.Issues, "Goroutine starvation detected (long runnable but not scheduled)")
+	// Check for WaitGroups that never complete (missing wg.Done)
+	a.detectStuckWaitGroups()
+	if n := len(a.summary.StuckWaitGroups); n > 0 {
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("%s waited on a WaitGroup for the entire trace (possible missing Done)", plural(n, "goroutine")))
+	}
+
+	// Check for goroutines that blocked and never came back
+	a.detectLeaks()
+	if n := len(a.summary.LeakedGoroutines); n > 0 {
+		a.summary.HasPerformanceIssues = true
+		verb := "appear"
+		if n == 1 {
+			verb = "appears"
+		}
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("%s %s leaked (blocked with no resumption)", plural(n, "goroutine"), verb))
+	}
+
+	// Check for a sustained runnable backlog beyond GOMAXPROCS
+	if o := a.summary.Oversubscription; o != nil {
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("Oversubscribed: %.0f runnable goroutines on average vs GOMAXPROCS=%d", o.AvgRunnable, o.GOMAXPROCS))
+	}
+
+	// Check for locks whose waits dwarf their critical section
+	for _, c := range a.summary.LockContention {
+		if IsHotLock(c) {
+			a.summary.HasPerformanceIssues = true
+			a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("Hot lock in %s: %s average wait vs ~%s hold", c.Site, formatDuration(c.AvgWait), formatDuration(c.Hold)))
+			break
+		}
+	}
+
+	// Check for channels whose blocked side outnumbers the side serving it
+	if len(a.summary.ChannelImbalances) > 0 {
+		c := a.summary.ChannelImbalances[0]
+		side, peer := "sender", "receiver"
+		if c.Reason == model.BlockChannelRecv {
+			side, peer = "receiver", "sender"
+		}
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("Channel imbalance in %s: %s blocked on %s", c.Site, plural(c.Blocked, side), plural(c.Peers, peer)))
+	}
+
+	// Check for the scheduler switching more than it executes
+	if c := a.summary.Churn; c != nil && c.Thrashing {
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("Context-switch thrash: %.0f switches/s with %s average run slice", c.PerSecond, formatDuration(c.AvgRunSlice)))
+	}
+
+	// Check for a goroutine count that only ever grows
+	if g := a.summary.Growth; g != nil {
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, fmt.Sprintf("Goroutine count grew by %d (%.0f/s) without levelling off", g.To-g.From, g.PerSecond))
+	}
+
+	// Check for long runnable periods (starvation detection). With GOMAXPROCS
+	// known, the runnable backlog must also have outgrown the Ps: runnable
+	// goroutines with a P to spare are a scheduling delay, not starvation
+	if maxRunnableRatio(a.goroutines) > maxRunnablePct/100 && !a.backlogFitsProcs() {
+		a.summary.HasPerformanceIssues = true
+		a.summary.Issues = append(a.summary.Issues, "Goroutine starvation detected (long runnable but not scheduled)")
+	}
+}
+
+// backlogFitsProcs reports whether the peak runnable count never exceeded
+// GOMAXPROCS; it is false when either is unknown
+func (a *Analyzer) backlogFitsProcs() bool {
+	return a.gomaxprocs > 0 && a.peakRunnable > 0 && a.peakRunnable <= a.gomaxprocs
+}
+
+// detectStuckWaitGroups finds goroutines still parked on a WaitGroup at the
+// end of the trace in a wait that covers nearly the whole trace. Consecutive
+// events are merged because the trace splits long waits at generation
+// boundaries. A wait that resumed was completed by its Done calls, and the
+// main goroutine is skipped: waiting on its workers is its job.
+func (a *Analyzer) detectStuckWaitGroups() {
+	a.summary.StuckWaitGroups = nil
+	if a.summary.WallTime <= 0 {
+		return
+	}
+	threshold := time.Duration(float64(a.summary.WallTime) * 0.9)
+
+	for gid, g := range a.goroutines {
+		if g.PendingBlock == nil || !g.PendingBlock.WaitGroup || gid == mainGoroutineID {
+			continue
+		}
+
+		// Walk back from the open wait over the events that join up with it
+		start := g.PendingBlock.StartTime
+		for i := len(g.BlockingEvents) - 1; i >= 0; i-- {
+			ev := g.BlockingEvents[i]
+			if !ev.WaitGroup || ev.EndTime != start {
 				break
 			}
+			start = ev.StartTime
+		}
+
+		if a.traceEnd-start >= threshold {
+			a.summary.StuckWaitGroups = append(a.summary.StuckWaitGroups, gid)
+		}
+	}
+
+	sort.Slice(a.summary.StuckWaitGroups, func(i, j int) bool {
+		return a.summary.StuckWaitGroups[i] < a.summary.StuckWaitGroups[j]
+	})
+}
+
+// leakMinRemaining is the share of the trace that must remain after a block
+// starts for it to count as a leak; a block that began shortly before the
+// capture stopped may simply not have finished yet
+const leakMinRemaining = 0.1
+
+// daemonPrefixes are entry functions of goroutines that wait for the whole
+// life of a program by design
+var daemonPrefixes = []string{"runtime.", "runtime/", "os/signal."}
+
+// detectLeaks finds goroutines still blocked at the end of the trace in a
+// wait that began with more than leakMinRemaining of the trace to go. The
+// main goroutine, the finalizer goroutine and runtime daemons are skipped.
+func (a *Analyzer) detectLeaks() {
+	a.summary.LeakedGoroutines = nil
+	if a.summary.WallTime <= 0 {
+		return
+	}
+	cutoff := a.traceEnd - time.Duration(float64(a.summary.WallTime)*leakMinRemaining)
+
+	for gid, g := range a.goroutines {
+		if g.PendingBlock == nil || g.PendingBlock.StartTime >= cutoff || gid == mainGoroutineID || g.Finalizer || isDaemon(g.Name) {
+			continue
+		}
+		// A goroutine blocked since before the trace started only showed its
+		// status, so there is no history to call it leaked on (e.g. idle pool
+		// workers, or anything parked in a flight-recorder snapshot)
+		if g.Preexisting && g.PendingBlock.StartTime <= g.CreatedAt {
+			continue
+		}
+		a.summary.LeakedGoroutines = append(a.summary.LeakedGoroutines, gid)
+	}
+
+	leaked := a.summary.LeakedGoroutines
+	sort.Slice(leaked, func(i, j int) bool {
+		si, sj := a.goroutines[leaked[i]].PendingBlock.StartTime, a.goroutines[leaked[j]].PendingBlock.StartTime
+		if si != sj {
+			return si < sj
+		}
+		return leaked[i] < leaked[j]
+	})
+}
+
+// isDaemon reports whether fn starts a goroutine that never exits by design
+func isDaemon(fn string) bool {
+	for _, prefix := range daemonPrefixes {
+		if strings.HasPrefix(fn, prefix) {
+			return true
 		}
 	}
+	return false
 }
 
 // GetBlockingReason returns the most common blocking reason
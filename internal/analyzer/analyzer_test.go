@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"bytes"
+	"maps"
+	"runtime/trace"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+	"github.com/goschedviz/goschedviz/internal/traceparser"
+)
+
+// TestDetectLeaksSkipsPreexistingWaits traces idle workers that blocked before
+// tracing started alongside a goroutine that blocks for good mid-trace; only
+// the latter has the history to be called leaked
+func TestDetectLeaksSkipsPreexistingWaits(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	work := make(chan int)
+	defer close(work)
+	for range 4 {
+		go func() {
+			for range work {
+			}
+		}()
+	}
+	// Let the workers park on the channel before the trace starts
+	time.Sleep(20 * time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	stuck := make(chan struct{})
+	defer close(stuck)
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		<-stuck
+	}()
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	trace.Stop()
+
+	result, err := traceparser.NewParser().Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := NewAnalyzer(result.Goroutines)
+	summary := a.Analyze()
+
+	var preexisting, fresh []uint64
+	for _, gid := range summary.LeakedGoroutines {
+		if result.Goroutines[gid].Preexisting {
+			preexisting = append(preexisting, gid)
+		} else {
+			fresh = append(fresh, gid)
+		}
+	}
+	if len(preexisting) > 0 {
+		t.Errorf("goroutines blocked before the trace reported as leaked: %v", preexisting)
+	}
+	if len(fresh) != 1 {
+		t.Errorf("got leaked goroutines %v, want the one blocked mid-trace", fresh)
+	}
+	if slices.Contains(summary.LeakedGoroutines, mainGoroutineID) {
+		t.Errorf("main goroutine reported as leaked")
+	}
+}
+
+// TestStuckWaitGroupsIgnoresCompletedWaits traces a goroutine that waits on a
+// WaitGroup for most of the trace until its worker calls Done; a wait that
+// resumed is not a missing Done
+func TestStuckWaitGroupsIgnoresCompletedWaits(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(50 * time.Millisecond)
+	}()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	<-done
+	trace.Stop()
+
+	result, err := traceparser.NewParser().Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary := NewAnalyzer(result.Goroutines).Analyze()
+	if len(summary.StuckWaitGroups) > 0 {
+		t.Errorf("completed WaitGroup waits reported as stuck: %v", summary.StuckWaitGroups)
+	}
+}
+
+func TestKeepTopLeavesOutTheRest(t *testing.T) {
+	goroutines := make(map[uint64]*model.GoroutineInfo)
+	for id := uint64(1); id <= 5; id++ {
+		g := model.NewGoroutineInfo(id, 0)
+		g.TotalBlocked = time.Duration(id) * time.Millisecond
+		goroutines[id] = g
+	}
+
+	kept, pruned := KeepTop(goroutines, 2)
+	if pruned != 3 || len(kept) != 2 || kept[5] == nil || kept[4] == nil {
+		t.Errorf("KeepTop(5 goroutines, 2) kept %v and pruned %d, want #4 and #5 and 3 pruned", slices.Sorted(maps.Keys(kept)), pruned)
+	}
+	if len(goroutines) != 5 {
+		t.Errorf("KeepTop modified its input: %d goroutines left", len(goroutines))
+	}
+}
@@ -0,0 +1,75 @@
+package analyzer
+
+import (
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Thresholds above which detectPerformanceIssues reports an issue; the
+// assertions check the same limits
+const (
+	maxChannelRecvPct  = 40
+	maxChannelSendPct  = 40
+	maxMutexPct        = 30
+	maxGCPct           = 15
+	maxTopGoroutinePct = 50
+	maxRunnablePct     = 70
+)
+
+// Assertion pairs a scheduler health metric with the threshold it must not
+// exceed, so a test can fail on it without knowing the rules
+type Assertion struct {
+	Name      string
+	Value     float64
+	Threshold float64
+	Pass      bool
+}
+
+// Assertions checks the summary against every performance issue rule, in a
+// fixed order
+func Assertions(summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo) []Assertion {
+	rules := []struct {
+		name      string
+		value     float64
+		threshold float64
+	}{
+		{"chan_recv_pct", summary.BlockingPercent[model.BlockChannelRecv], maxChannelRecvPct},
+		{"chan_send_pct", summary.BlockingPercent[model.BlockChannelSend], maxChannelSendPct},
+		{"mutex_pct", summary.BlockingPercent[model.BlockMutexLock], maxMutexPct},
+		{"gc_pct", summary.BlockingPercent[model.BlockGC], maxGCPct},
+		{"top_goroutine_blocked_pct", topBlockedPct(summary), maxTopGoroutinePct},
+		{"max_runnable_pct", maxRunnableRatio(goroutines) * 100, maxRunnablePct},
+		{"stuck_waitgroups", float64(len(summary.StuckWaitGroups)), 0},
+	}
+
+	assertions := make([]Assertion, len(rules))
+	for i, r := range rules {
+		assertions[i] = Assertion{
+			Name:      r.name,
+			Value:     r.value,
+			Threshold: r.threshold,
+			Pass:      r.value <= r.threshold,
+		}
+	}
+	return assertions
+}
+
+// topBlockedPct is the share of all blocked time held by the most blocked
+// goroutine
+func topBlockedPct(summary *model.Summary) float64 {
+	if len(summary.TopBlocked) == 0 || summary.TotalBlockedTime <= 0 {
+		return 0
+	}
+	return float64(summary.TopBlocked[0].TotalBlocked) / float64(summary.TotalBlockedTime) * 100
+}
+
+// maxRunnableRatio is the largest fraction of its scheduled time any goroutine
+// spent runnable rather than running
+func maxRunnableRatio(goroutines map[uint64]*model.GoroutineInfo) float64 {
+	var worst float64
+	for _, g := range goroutines {
+		if g.TotalRunnable > 0 && g.TotalRuntime > 0 {
+			worst = max(worst, float64(g.TotalRunnable)/float64(g.TotalRunnable+g.TotalRuntime))
+		}
+	}
+	return worst
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+const (
+	// maxChainDepth bounds how many goroutines a chain is followed through
+	maxChainDepth = 8
+
+	// maxChains is how many distinct chains are reported
+	maxChains = 10
+)
+
+// findBlockingChains follows each wake-up back through its waker: if the
+// goroutine that woke A was itself blocked while A waited, its own waker is
+// part of the cause, and so on until a goroutine that was not blocked, which
+// is the root cause. Chains over the same goroutines are merged.
+func (a *Analyzer) findBlockingChains() {
+	merged := make(map[string]*model.BlockingChain)
+
+	for gid, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			if ev.WakerID == 0 || ev.WakerID == gid {
+				continue
+			}
+			// A chain needs the waker to have been blocked too; a direct
+			// wait is already covered by the wait-for graph
+			path, reasons := a.followChain(gid, ev)
+			if len(reasons) < 2 {
+				continue
+			}
+
+			key := chainKey(path)
+			c, ok := merged[key]
+			if !ok {
+				c = &model.BlockingChain{Goroutines: path, Reasons: reasons}
+				merged[key] = c
+			}
+			c.Occurrences++
+			c.TotalLatency += ev.Duration
+			if ev.Duration > c.MaxLatency {
+				c.MaxLatency = ev.Duration
+			}
+		}
+	}
+
+	chains := make([]model.BlockingChain, 0, len(merged))
+	for _, c := range merged {
+		chains = append(chains, *c)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		if chains[i].TotalLatency != chains[j].TotalLatency {
+			return chains[i].TotalLatency > chains[j].TotalLatency
+		}
+		return chainKey(chains[i].Goroutines) < chainKey(chains[j].Goroutines)
+	})
+	if len(chains) > maxChains {
+		chains = chains[:maxChains]
+	}
+	a.summary.BlockingChains = chains
+}
+
+// followChain returns the goroutines in the causal chain behind ev, head
+// first, along with the reason each of them was blocked
+func (a *Analyzer) followChain(gid uint64, ev model.BlockingEvent) ([]uint64, []model.BlockingReason) {
+	path := []uint64{gid}
+	reasons := []model.BlockingReason{ev.Reason}
+	seen := map[uint64]bool{gid: true}
+
+	for len(path) < maxChainDepth {
+		waker := ev.WakerID
+		if waker == 0 || seen[waker] {
+			break
+		}
+		path = append(path, waker)
+		seen[waker] = true
+
+		cause, ok := blockedDuring(a.goroutines[waker], ev)
+		if !ok {
+			break
+		}
+		reasons = append(reasons, cause.Reason)
+		ev = cause
+	}
+	return path, reasons
+}
+
+// blockedDuring finds g's latest block that ended while ev was waiting, i.e.
+// the stall that delayed g from unblocking ev
+func blockedDuring(g *model.GoroutineInfo, ev model.BlockingEvent) (model.BlockingEvent, bool) {
+	if g == nil {
+		return model.BlockingEvent{}, false
+	}
+	events := g.BlockingEvents
+	i := sort.Search(len(events), func(i int) bool {
+		return events[i].EndTime > ev.EndTime
+	})
+	if i == 0 {
+		return model.BlockingEvent{}, false
+	}
+	cause := events[i-1]
+	if cause.EndTime < ev.StartTime {
+		return model.BlockingEvent{}, false
+	}
+	return cause, true
+}
+
+// chainKey identifies a chain by its goroutines
+func chainKey(path []uint64) string {
+	ids := make([]string, len(path))
+	for i, id := range path {
+		ids[i] = strconv.FormatUint(id, 10)
+	}
+	return strings.Join(ids, ">")
+}
@@ -0,0 +1,81 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Channel imbalance heuristic: the trace carries no channel identity, so a
+// channel is approximated by the call site of the blocked operation, and its
+// peers by the goroutines that woke the blocked ones. Many goroutines
+// blocked at one site and woken by a few peers is a fan-in (or fan-out) the
+// other side can't keep up with.
+const (
+	imbalanceMinBlocked = 4
+	imbalanceRatio      = 3
+	imbalanceMinShare   = 0.05
+	maxImbalances       = 3
+)
+
+// detectChannelImbalance finds channel sites where the blocked goroutines
+// outnumber the goroutines serving them
+func (a *Analyzer) detectChannelImbalance() {
+	type key struct {
+		reason model.BlockingReason
+		site   string
+	}
+	type site struct {
+		blocked map[uint64]bool
+		peers   map[uint64]bool
+		waited  time.Duration
+	}
+	sites := make(map[key]*site)
+
+	for gid, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			if (ev.Reason != model.BlockChannelSend && ev.Reason != model.BlockChannelRecv) || ev.Site() == "" {
+				continue
+			}
+			k := key{ev.Reason, ev.Site()}
+			s, ok := sites[k]
+			if !ok {
+				s = &site{blocked: make(map[uint64]bool), peers: make(map[uint64]bool)}
+				sites[k] = s
+			}
+			s.blocked[gid] = true
+			if ev.WakerID != 0 && ev.WakerID != gid {
+				s.peers[ev.WakerID] = true
+			}
+			s.waited += ev.Duration
+		}
+	}
+
+	minWait := time.Duration(float64(a.summary.TotalBlockedTime) * imbalanceMinShare)
+	var imbalances []model.ChannelImbalance
+	for k, s := range sites {
+		if len(s.peers) == 0 || len(s.blocked) < imbalanceMinBlocked ||
+			len(s.blocked) < imbalanceRatio*len(s.peers) || s.waited < minWait {
+			continue
+		}
+		imbalances = append(imbalances, model.ChannelImbalance{
+			Site:        k.site,
+			Reason:      k.reason,
+			Blocked:     len(s.blocked),
+			Peers:       len(s.peers),
+			BlockedTime: s.waited,
+		})
+	}
+
+	sort.Slice(imbalances, func(i, j int) bool {
+		if imbalances[i].BlockedTime != imbalances[j].BlockedTime {
+			return imbalances[i].BlockedTime > imbalances[j].BlockedTime
+		}
+		return imbalances[i].Site < imbalances[j].Site
+	})
+	if len(imbalances) > maxImbalances {
+		imbalances = imbalances[:maxImbalances]
+	}
+	a.summary.ChannelImbalances = imbalances
+}
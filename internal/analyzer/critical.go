@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// maxCriticalGoroutines is how many goroutines the critical path lists
+const maxCriticalGoroutines = 5
+
+// findCriticalPath attributes the root goroutine's blocking along its wakers.
+// The root is main when present, otherwise the longest-lived goroutine.
+func (a *Analyzer) findCriticalPath() {
+	root := a.criticalRoot()
+	if root == nil || root.TotalBlocked == 0 {
+		return
+	}
+
+	byGoroutine := make(map[uint64]time.Duration)
+	byReason := make(map[model.BlockingReason]time.Duration)
+	for _, ev := range root.BlockingEvents {
+		a.attributeWait(root.ID, ev, map[uint64]bool{root.ID: true}, byGoroutine, byReason)
+	}
+
+	cp := &model.CriticalPath{Root: root.ID, ByReason: byReason}
+	for gid, d := range byGoroutine {
+		cp.Blocked += d
+		cp.Goroutines = append(cp.Goroutines, model.GoroutineShare{ID: gid, Duration: d})
+	}
+	sort.Slice(cp.Goroutines, func(i, j int) bool {
+		if cp.Goroutines[i].Duration != cp.Goroutines[j].Duration {
+			return cp.Goroutines[i].Duration > cp.Goroutines[j].Duration
+		}
+		return cp.Goroutines[i].ID < cp.Goroutines[j].ID
+	})
+	if len(cp.Goroutines) > maxCriticalGoroutines {
+		cp.Goroutines = cp.Goroutines[:maxCriticalGoroutines]
+	}
+	cp.OffPath = max(a.summary.TotalBlockedTime-cp.Blocked, 0)
+	a.summary.CriticalPath = cp
+}
+
+// criticalRoot picks the goroutine whose latency the user sees
+func (a *Analyzer) criticalRoot() *model.GoroutineInfo {
+	if g, ok := a.goroutines[mainGoroutineID]; ok {
+		return g
+	}
+	var root *model.GoroutineInfo
+	var longest time.Duration
+	for _, g := range a.goroutines {
+		end := a.traceEnd
+		if g.TerminatedAt > 0 {
+			end = g.TerminatedAt
+		}
+		if life := end - g.CreatedAt; root == nil || life > longest || (life == longest && g.ID < root.ID) {
+			root, longest = g, life
+		}
+	}
+	return root
+}
+
+// attributeWait charges ev, a wait of gid, to whoever held it up: the parts
+// during which its waker was itself blocked go to the waker (recursively),
+// the rest to gid and ev's reason. path guards against cycles.
+func (a *Analyzer) attributeWait(gid uint64, ev model.BlockingEvent, path map[uint64]bool,
+	byGoroutine map[uint64]time.Duration, byReason map[model.BlockingReason]time.Duration) {
+	var covered time.Duration
+	waker := a.goroutines[ev.WakerID]
+	if waker != nil && !path[waker.ID] && len(path) < maxChainDepth {
+		path[waker.ID] = true
+		events := waker.BlockingEvents
+		i := sort.Search(len(events), func(i int) bool {
+			return events[i].EndTime > ev.StartTime
+		})
+		for ; i < len(events) && events[i].StartTime < ev.EndTime; i++ {
+			c := events[i]
+			c.StartTime = max(c.StartTime, ev.StartTime)
+			c.EndTime = min(c.EndTime, ev.EndTime)
+			c.Duration = c.EndTime - c.StartTime
+			if c.Duration <= 0 {
+				continue
+			}
+			covered += c.Duration
+			a.attributeWait(waker.ID, c, path, byGoroutine, byReason)
+		}
+		delete(path, waker.ID)
+	}
+
+	if rest := ev.Duration - covered; rest > 0 {
+		byGoroutine[gid] += rest
+		byReason[ev.Reason] += rest
+	}
+}
@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// A goroutine has a two-state cycle when at least minCycleAlternations of its
+// reason changes, and minCycleShare of all of them, flip between one pair
+const (
+	minCycleAlternations = 20
+	minCycleShare        = 0.8
+	maxReasonCycles      = 10
+)
+
+// findReasonCycles looks for goroutines bouncing between two blocking reasons,
+// the signature of a pipeline stage that could batch or overlap its work
+func (a *Analyzer) findReasonCycles() {
+	var cycles []model.ReasonCycle
+
+	for gid, g := range a.goroutines {
+		if c, ok := dominantCycle(g.ReasonSequence); ok {
+			c.GoroutineID = gid
+			c.Label = g.Label
+			cycles = append(cycles, c)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		if cycles[i].Alternations != cycles[j].Alternations {
+			return cycles[i].Alternations > cycles[j].Alternations
+		}
+		return cycles[i].GoroutineID < cycles[j].GoroutineID
+	})
+	if len(cycles) > maxReasonCycles {
+		cycles = cycles[:maxReasonCycles]
+	}
+	a.summary.ReasonCycles = cycles
+}
+
+// dominantCycle counts reason changes per unordered pair and reports the most
+// frequent pair if it dominates the sequence
+func dominantCycle(seq []model.BlockingReason) (model.ReasonCycle, bool) {
+	type pair struct{ a, b model.BlockingReason }
+	counts := make(map[pair]int)
+	changes := 0
+	for i := 1; i < len(seq); i++ {
+		x, y := seq[i-1], seq[i]
+		if x == y {
+			continue
+		}
+		if x > y {
+			x, y = y, x
+		}
+		counts[pair{x, y}]++
+		changes++
+	}
+
+	var best pair
+	bestCount := 0
+	for p, n := range counts {
+		if n > bestCount || (n == bestCount && (p.a < best.a || (p.a == best.a && p.b < best.b))) {
+			best, bestCount = p, n
+		}
+	}
+
+	if bestCount < minCycleAlternations {
+		return model.ReasonCycle{}, false
+	}
+	share := float64(bestCount) / float64(changes)
+	if share < minCycleShare {
+		return model.ReasonCycle{}, false
+	}
+	return model.ReasonCycle{A: best.a, B: best.b, Alternations: bestCount, Share: share}, true
+}
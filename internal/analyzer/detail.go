@@ -0,0 +1,48 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// BlockGroup aggregates a goroutine's blocking events that share a key, such
+// as the same waker or the same call site
+type BlockGroup struct {
+	Key   string
+	Count int
+	Total time.Duration
+}
+
+// GroupBlocks buckets g's blocking events for reason by key, largest total
+// first. Events for which key returns "" are skipped.
+func GroupBlocks(g *model.GoroutineInfo, reason model.BlockingReason, key func(model.BlockingEvent) string) []BlockGroup {
+	index := make(map[string]int)
+	var groups []BlockGroup
+	for _, ev := range g.BlockingEvents {
+		if ev.Reason != reason {
+			continue
+		}
+		k := key(ev)
+		if k == "" {
+			continue
+		}
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, BlockGroup{Key: k})
+		}
+		groups[i].Count++
+		groups[i].Total += ev.Duration
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Total != groups[j].Total {
+			return groups[i].Total > groups[j].Total
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
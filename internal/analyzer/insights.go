@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/goschedviz/goschedviz/internal/model"
@@ -13,6 +14,9 @@ type NarrativeInsight struct {
 	Observation string
 	Suggestion  string
 	Severity    string // info, warning, critical
+
+	// DocLink points to Go documentation explaining the issue (may be empty)
+	DocLink string
 }
 
 // GenerateInsights analyzes a summary and creates human-like narratives
@@ -25,36 +29,306 @@ func GenerateInsights(summary *model.Summary) []NarrativeInsight {
 			Title:       "Channel Bottleneck Detected",
 			Observation: fmt.Sprintf("Your application is spending %.1f%% of its total blocked time waiting for channel receives.", summary.BlockingPercent[model.BlockChannelRecv]),
 			Suggestion:  "This often indicates 'Slow Producers' or unbuffered channels causing synchronization stalls. Consider increasing channel buffers or balancing workload.",
+			DocLink:     "https://go.dev/doc/effective_go#channels",
 			Severity:    "critical",
 		})
 	}
 
-	// 2. Starvation Analysis
+	// 2. Many goroutines blocked on a channel served by few peers
+	for _, c := range summary.ChannelImbalances {
+		insights = append(insights, channelImbalanceInsight(c))
+	}
+
+	// 3. Starvation Analysis
 	if summary.HasPerformanceIssues {
 		for _, issue := range summary.Issues {
 			if issue == "Goroutine starvation detected (long runnable but not scheduled)" {
-				insights = append(insights, NarrativeInsight{
-					Title:       "CPU Starvation",
-					Observation: "I noticed several goroutines are ready to run (Runnable) but are waiting too long for a CPU slot.",
-					Suggestion:  "This usually happens when GOMAXPROCS is too low or when a few goroutines are 'hogging' the CPU with tight loops. Check for non-preemptive code.",
-					Severity:    "warning",
-				})
+				insights = append(insights, starvationInsights(summary.RunnableStarvation)...)
 			}
 		}
 	}
 
-	// 3. GC Pressure
+	// 4. GC Pressure
 	if summary.BlockingPercent[model.BlockGC] > 15 {
 		insights = append(insights, NarrativeInsight{
 			Title:       "High GC Pressure",
 			Observation: fmt.Sprintf("Garbage Collection is responsible for %.1f%% of system pauses.", summary.BlockingPercent[model.BlockGC]),
 			Suggestion:  "High GC overhead often stems from excessive short-lived allocations. Try using sync.Pool to reuse objects and profile memory with 'go tool pprof --alloc_objects'.",
+			DocLink:     "https://go.dev/doc/gc-guide",
 			Severity:    "warning",
 		})
 	}
 
-	// 4. General Positive Insight
-	if !summary.HasPerformanceIssues && summary.TotalGoroutines > 0 {
+	// 5. Frequent GC cycles, even if each pause is short
+	if gc := summary.GC; gc != nil && gc.Cycles >= gcFrequentMinCycles && gc.PerSecond > gcFrequentPerSecond {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Frequent GC Cycles",
+			Observation: fmt.Sprintf("The GC ran %d cycles (%.1f per second), with %s average and %s worst stop-the-world pause.", gc.Cycles, gc.PerSecond, formatDuration(gc.AvgPause), formatDuration(gc.MaxPause)),
+			Suggestion:  "Each cycle costs mark work and write barriers even when pauses are short; running this often means the heap is churning. Find the hot allocation sites with 'go tool pprof -sample_index=alloc_space', reuse buffers, or raise GOGC / set GOMEMLIMIT so the heap can grow between cycles.",
+			DocLink:     "https://go.dev/doc/gc-guide",
+			Severity:    "warning",
+		})
+	}
+
+	// 6. GC assists and finalizers, which the GC bucket would otherwise hide
+	if pct := summary.BlockingPercent[model.BlockGCAssist]; pct > 10 {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Goroutines Paying for GC",
+			Observation: fmt.Sprintf("%d goroutines spent %.1f%% of blocked time (%s) in GC assist waits.", summary.GoroutinesByReason[model.BlockGCAssist], pct, formatDuration(summary.BlockingBreakdown[model.BlockGCAssist])),
+			Suggestion:  "The GC makes goroutines that allocate faster than it can mark help with marking, and stalls them when they can't. Cut the allocation rate on hot paths (reuse buffers, sync.Pool) or raise GOGC / set GOMEMLIMIT to give the collector more headroom.",
+			DocLink:     "https://go.dev/doc/gc-guide",
+			Severity:    "warning",
+		})
+	}
+	finalizerBlocked := summary.BlockingBreakdown[model.BlockFinalizer]
+	if finalizerBlocked >= time.Millisecond || (summary.TotalRuntime > 0 && summary.FinalizerRuntime*20 > summary.TotalRuntime) {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Heavy Finalizer Use",
+			Observation: fmt.Sprintf("The finalizer goroutine ran for %s and was blocked inside finalizers for %s.", formatDuration(summary.FinalizerRuntime), formatDuration(finalizerBlocked)),
+			Suggestion:  "All finalizers run one at a time on a single goroutine, so a slow or blocking finalizer delays every other one and keeps their memory alive. Release resources with an explicit Close and keep finalizers (or runtime.AddCleanup functions) short and non-blocking.",
+			DocLink:     "https://pkg.go.dev/runtime#AddCleanup",
+			Severity:    "warning",
+		})
+	}
+
+	// 7. Syscall-heavy blocking
+	if pct := summary.BlockingPercent[model.BlockSyscall]; pct > 30 {
+		total := summary.BlockingBreakdown[model.BlockSyscall]
+		var avg time.Duration
+		if n := summary.BlockingEventCount[model.BlockSyscall]; n > 0 {
+			avg = total / time.Duration(n)
+		}
+		if avg > time.Millisecond {
+			insights = append(insights, NarrativeInsight{
+				Title:       "Blocking Syscalls",
+				Observation: fmt.Sprintf("%d goroutines spent a combined %s (%.1f%% of blocked time) in system calls, averaging %s per call.", summary.GoroutinesByReason[model.BlockSyscall], formatDuration(total), pct, formatDuration(avg)),
+				Suggestion:  "Long syscalls pin an OS thread each. Prefer the netpoller (non-blocking network I/O) where possible, and route unavoidable blocking calls (file I/O, cgo) through a bounded worker pool.",
+				DocLink:     "https://pkg.go.dev/runtime#LockOSThread",
+				Severity:    "warning",
+			})
+		}
+	}
+
+	// 8. Time spent inside C calls
+	if pct := summary.BlockingPercent[model.BlockCgo]; pct > 20 {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Cgo-Heavy Workload",
+			Observation: fmt.Sprintf("%d goroutines spent a combined %s (%.1f%% of blocked time) inside cgo calls across %d calls.", summary.GoroutinesByReason[model.BlockCgo], formatDuration(summary.BlockingBreakdown[model.BlockCgo]), pct, summary.BlockingEventCount[model.BlockCgo]),
+			Suggestion:  "Every in-flight cgo call holds its own OS thread, so concurrent calls force the runtime to create threads (up to the 10000-thread limit) while Ps sit idle. Cap concurrent C calls with a semaphore, batch small calls into fewer larger ones, or move long-running C work to a dedicated goroutine pool.",
+			DocLink:     "https://pkg.go.dev/cmd/cgo",
+			Severity:    "warning",
+		})
+	}
+
+	// 9. How select blocks end
+	if total := summary.BlockingEventCount[model.BlockSelect]; total >= 10 && summary.BlockingPercent[model.BlockSelect] > 10 {
+		timeoutPct := float64(summary.SelectOutcomes[model.SelectTimeout]) / float64(total) * 100
+		recvPct := float64(summary.SelectOutcomes[model.SelectRecv]) / float64(total) * 100
+		switch {
+		case timeoutPct > 50:
+			insights = append(insights, NarrativeInsight{
+				Title:       "Selects Mostly Time Out",
+				Observation: fmt.Sprintf("%.0f%% of select blocks ended on a timer/timeout case rather than a channel operation.", timeoutPct),
+				Suggestion:  "The channels being selected on are rarely ready in time, which usually points at a slow dependency. Check what feeds those channels, or revisit whether the timeout is too short.",
+				DocLink:     "https://go.dev/blog/context",
+				Severity:    "warning",
+			})
+		case recvPct > 50:
+			insights = append(insights, NarrativeInsight{
+				Title:       "Selects Waiting on Producers",
+				Observation: fmt.Sprintf("%.0f%% of select blocks ended when a value was received.", recvPct),
+				Suggestion:  "Consumers are outpacing producers. Speed up or add producers, or batch work so each receive carries more.",
+				DocLink:     "https://go.dev/blog/pipelines",
+				Severity:    "info",
+			})
+		}
+	}
+
+	// 10. WaitGroup that never completes
+	if n := len(summary.StuckWaitGroups); n > 0 {
+		insights = append(insights, NarrativeInsight{
+			Title:       "WaitGroup Never Completed",
+			Observation: fmt.Sprintf("%s (e.g. #%d) sat in WaitGroup.Wait for essentially the whole trace and never resumed.", plural(n, "goroutine"), summary.StuckWaitGroups[0]),
+			Suggestion:  "This is the classic missing wg.Done() bug: check every path (including early returns and panics) calls Done, ideally via 'defer wg.Done()' right after the goroutine starts. If the wait is deliberate (e.g. main waiting for shutdown), it is safe to ignore.",
+			DocLink:     "https://pkg.go.dev/sync#WaitGroup",
+			Severity:    "warning",
+		})
+	}
+
+	// 11. Spawn/drain oscillation
+	if osc := summary.Oscillation; osc != nil {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Oscillating Goroutine Count",
+			Observation: fmt.Sprintf("The live goroutine count rises and drains by ~%d goroutines every %s (%d cycles observed).", osc.Amplitude, formatDuration(osc.Period), osc.Cycles),
+			Suggestion:  "A sawtooth goroutine count often points at a retry loop or bursty fan-out that spawns goroutines per batch. Consider a fixed worker pool or backoff with jitter.",
+			DocLink:     "https://go.dev/blog/pipelines",
+			Severity:    "warning",
+		})
+	}
+
+	// 12. Mass termination
+	for _, c := range summary.Cliffs {
+		obs := fmt.Sprintf("The live goroutine count fell from %d to %d within %s at +%s.", c.From, c.To, formatDuration(c.Duration), formatDuration(c.At))
+		if c.UnblockCount > 0 {
+			obs += fmt.Sprintf(" %d of the exiting goroutines had just been woken from %s.", c.UnblockCount, c.UnblockReason)
+		}
+		insights = append(insights, NarrativeInsight{
+			Title:       "Goroutine Cliff",
+			Observation: obs,
+			Suggestion:  "A mass exit like this is usually a context cancellation fanning out, a shutdown, or errors cascading through a pipeline. If it wasn't a planned shutdown, check what closed the channel or cancelled the context they were waiting on, and whether the work they dropped is retried.",
+			DocLink:     "https://go.dev/blog/context",
+			Severity:    "info",
+		})
+	}
+
+	// 13. Steady growth with no plateau
+	if g := summary.Growth; g != nil {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Goroutine Leak Suspected",
+			Observation: fmt.Sprintf("The goroutine count grew by %d over the capture (from ~%d to ~%d, %.1f per second) with no plateau — likely a leak. A straight line explains %.0f%% of the timeline.", g.To-g.From, g.From, g.To, g.PerSecond, g.R2*100),
+			Suggestion:  "Goroutines that are started but never exit usually wait on a channel nobody sends to, or a context nobody cancels. Inspect the newest goroutines ('goschedviz inspect') to see where they block, and make sure every spawn has an exit path. Capture a longer trace to confirm the trend continues.",
+			DocLink:     "https://go.dev/blog/pipelines",
+			Severity:    "critical",
+		})
+	}
+
+	// 14. Goroutines that blocked and never resumed
+	if n := len(summary.LeakedGoroutines); n > 0 {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Leaked Goroutines",
+			Observation: fmt.Sprintf("%s blocked and never resumed before the trace ended (longest first: %s).", plural(n, "goroutine"), goroutineList(summary.LeakedGoroutines, 3)),
+			Suggestion:  "A goroutine parked on a channel, lock or network read that nothing will ever complete lives forever. Give every such wait a way out: select on ctx.Done() next to the channel operation, use context.WithTimeout or deadlines for I/O, and make sure the other side closes the channel or cancels the context when it gives up.",
+			DocLink:     "https://go.dev/blog/context",
+			Severity:    "warning",
+		})
+	}
+
+	// 15. More runnable goroutines than Ps can serve
+	if o := summary.Oversubscription; o != nil {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Oversubscribed Scheduler",
+			Observation: fmt.Sprintf("On average %.0f goroutines were runnable against GOMAXPROCS=%d.", o.AvgRunnable, o.GOMAXPROCS),
+			Suggestion:  "Runnable goroutines far beyond the number of Ps just queue up and context-switch. Consider limiting concurrency with a semaphore or a worker pool sized near GOMAXPROCS.",
+			DocLink:     "https://pkg.go.dev/runtime#GOMAXPROCS",
+			Severity:    "warning",
+		})
+	}
+
+	// 16. The scheduler switching more than it executes
+	if c := summary.Churn; c != nil && c.Thrashing {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Context-Switch Thrash",
+			Observation: fmt.Sprintf("Goroutines were switched onto a P %d times (%.0f per second), but ran only %s on average each time.", c.Switches, c.PerSecond, formatDuration(c.AvgRunSlice)),
+			Suggestion:  "When run slices are this short, scheduling overhead rivals the work itself. Batch work so each goroutine does more per wake-up (buffer channel sends, process items in chunks), and cap concurrency so fewer goroutines compete for the Ps.",
+			DocLink:     "https://go.dev/doc/diagnostics#execution-tracer",
+			Severity:    "warning",
+		})
+	}
+
+	// 17. Far more goroutines than ever ran at once
+	if c := summary.ConcurrencyCap; c != nil {
+		insights = append(insights, NarrativeInsight{
+			Title:       "Concurrency Beyond Useful Parallelism",
+			Observation: fmt.Sprintf("You ran up to %d goroutines while they queued for GOMAXPROCS=%d, but only ~%.0f were running or runnable at once; a worker pool of ~%d would suffice.", c.PeakGoroutines, summary.GOMAXPROCS, c.Parallelism, c.Recommended),
+			Suggestion:  "Goroutines beyond what can run in parallel only wait, costing memory and scheduler work. Bound the fan-out with a worker pool or a semaphore (e.g. golang.org/x/sync/semaphore or errgroup.SetLimit) sized to the recommendation.",
+			DocLink:     "https://pkg.go.dev/golang.org/x/sync/semaphore",
+			Severity:    "info",
+		})
+	}
+
+	// 18. Goroutines hopping between OS threads
+	if heavy := summary.MigrationHeavy; len(heavy) > 0 {
+		g := heavy[0]
+		insights = append(insights, NarrativeInsight{
+			Title:       "Goroutines Hopping Between Threads",
+			Observation: fmt.Sprintf("%d goroutines resumed on a different OS thread for at least half of their runs; %s moved %d times in %d runs.", len(heavy), g.DisplayName(), g.Migrations, g.Schedules),
+			Suggestion:  "Each move to another M (and usually another P) leaves the goroutine's working set in the old core's cache. This is typical of goroutines that block and wake very often, so batch work per wakeup, avoid ping-ponging on channels between goroutines, and check that GOMAXPROCS matches the CPUs the process may actually use.",
+			DocLink:     "https://pkg.go.dev/runtime#GOMAXPROCS",
+			Severity:    "info",
+		})
+	}
+
+	// 19. Hot lock with a tiny critical section
+	for _, c := range summary.LockContention {
+		if !IsHotLock(c) {
+			continue
+		}
+		insights = append(insights, NarrativeInsight{
+			Title:       "Hot Lock (Possible False Sharing)",
+			Observation: fmt.Sprintf("%d goroutines waited %s on average for the lock in %s, which is only held for ~%s at a time.", c.Waiters, formatDuration(c.AvgWait), c.Site, formatDuration(c.Hold)),
+			Suggestion:  "When waits dwarf a tiny critical section, the lock itself (or data sharing its cache line) is the bottleneck. Shard the lock (e.g. per-key or per-CPU stripes), switch to atomics, or pad hot structs to a 64-byte cache line to avoid false sharing.",
+			DocLink:     "https://pkg.go.dev/sync#Mutex",
+			Severity:    "warning",
+		})
+		break
+	}
+
+	// 20. Goroutines looping between two blocking reasons
+	if len(summary.ReasonCycles) > 0 {
+		c := summary.ReasonCycles[0]
+		insights = append(insights, NarrativeInsight{
+			Title:       "Two-State Blocking Loop",
+			Observation: fmt.Sprintf("%d goroutine(s) alternate between %s and %s; e.g. #%d flipped %d times (%.0f%% of its reason changes).", len(summary.ReasonCycles), c.A, c.B, c.GoroutineID, c.Alternations, c.Share*100),
+			Suggestion:  "A goroutine that waits on one thing, then another, over and over is a serial pipeline stage. Batch the work per wake-up, or split the two waits into separate goroutines connected by a buffered channel so they overlap.",
+			DocLink:     "https://go.dev/doc/diagnostics#execution-tracer",
+			Severity:    "info",
+		})
+	}
+
+	// 21. Blocking that never delayed the root goroutine
+	if cp := summary.CriticalPath; cp != nil && summary.HasPerformanceIssues && cp.Blocked > 0 && float64(cp.OffPath) >= criticalOffPathShare*float64(summary.TotalBlockedTime) {
+		var top model.BlockingReason
+		for r, d := range cp.ByReason {
+			if d > cp.ByReason[top] || (d == cp.ByReason[top] && r < top) {
+				top = r
+			}
+		}
+		insights = append(insights, NarrativeInsight{
+			Title:       "Most Blocking Is Off the Critical Path",
+			Observation: fmt.Sprintf("Only %s of %s blocked time held up goroutine #%d; on that path %s dominates (%s).", formatDuration(cp.Blocked), formatDuration(summary.TotalBlockedTime), cp.Root, top, formatDuration(cp.ByReason[top])),
+			Suggestion:  "Blocking in background goroutines that the root never waited on doesn't change end-to-end latency. Start with the critical-path reason and the goroutines listed under CRITICAL PATH; shaving the rest mostly saves idle time.",
+			DocLink:     "https://go.dev/doc/diagnostics#execution-tracer",
+			Severity:    "info",
+		})
+	}
+
+	// 22. How concentrated blocking is
+	if len(summary.TopBlocked) > 0 && summary.TotalBlockedTime > 0 {
+		top := summary.TopBlocked[0]
+		share := float64(top.TotalBlocked) / float64(summary.TotalBlockedTime) * 100
+		switch {
+		case summary.BlockingGini >= giniLocalized:
+			insights = append(insights, NarrativeInsight{
+				Title:       "Blocking Is Localized",
+				Observation: fmt.Sprintf("Blocking is concentrated in a few goroutines (Gini %.2f); %s alone accounts for %.0f%% of blocked time.", summary.BlockingGini, top.DisplayName(), share),
+				Suggestion:  "A targeted fix should pay off: start with the top blocked goroutines and the code they wait in rather than changing the overall design.",
+				Severity:    "info",
+			})
+		case summary.BlockingGini <= giniSystemic && summary.TotalGoroutines >= 10:
+			insights = append(insights, NarrativeInsight{
+				Title:       "Blocking Is Systemic",
+				Observation: fmt.Sprintf("Blocked time is spread evenly across goroutines (Gini %.2f); no single goroutine stands out.", summary.BlockingGini),
+				Suggestion:  "Fixing individual goroutines won't move the needle. Look at the shared design: the common channel, lock or pool they all wait on, and how work is partitioned between them.",
+				Severity:    "info",
+			})
+		}
+	}
+
+	// 23. Barely any blocking: the remaining cost is CPU work
+	cpuBound := IsCPUBound(summary)
+	if cpuBound {
+		busy := float64(summary.IdealWallTime) / float64(summary.WallTime) * 100
+		insights = append(insights, NarrativeInsight{
+			Title:       "CPU-Bound Program",
+			Observation: fmt.Sprintf("Every P was busy for ~%.0f%% of the trace (%s of CPU time in total), so blocking cost little and the scheduler is not what slows this program down.", min(busy, 100), formatDuration(summary.TotalRuntime)),
+			Suggestion:  "Scheduler tuning won't help here. Capture a CPU profile (e.g. /debug/pprof/profile?seconds=30) and inspect it with 'go tool pprof -top' or 'go tool pprof -http=:8080' to find the hot functions.",
+			DocLink:     "https://go.dev/blog/pprof",
+			Severity:    "info",
+		})
+	}
+
+	// 24. General Positive Insight
+	if !summary.HasPerformanceIssues && !cpuBound && summary.TotalGoroutines > 0 {
 		insights = append(insights, NarrativeInsight{
 			Title:       "Healthy Scheduler State",
 			Observation: "The scheduler seems well-balanced. No significant contention or starvation was detected.",
@@ -66,6 +340,36 @@ func GenerateInsights(summary *model.Summary) []NarrativeInsight {
 	return insights
 }
 
+// GC cycle rate above which collection counts as frequent; short traces need
+// a few cycles before the rate means anything
+const (
+	gcFrequentPerSecond = 10
+	gcFrequentMinCycles = 5
+)
+
+// criticalOffPathShare is the share of blocked time off the critical path
+// above which the insight points attention back at the path
+const criticalOffPathShare = 0.5
+
+// Gini thresholds for calling blocking localized or systemic
+const (
+	giniLocalized = 0.6
+	giniSystemic  = 0.3
+)
+
+// cpuBoundUtilization is the fraction of P time spent running above which
+// blocking can't be what limits throughput
+const cpuBoundUtilization = 0.8
+
+// IsCPUBound reports whether the Ps were saturated with running goroutines for
+// most of the trace; it needs GOMAXPROCS, so it is false without it
+func IsCPUBound(summary *model.Summary) bool {
+	if summary.IdealWallTime <= 0 || summary.WallTime <= 0 {
+		return false
+	}
+	return float64(summary.IdealWallTime) >= cpuBoundUtilization*float64(summary.WallTime)
+}
+
 // formatDuration converts duration to human-readable string (helper)
 func formatDuration(d time.Duration) string {
 	if d < time.Millisecond {
@@ -76,3 +380,81 @@ func formatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%.2fs", d.Seconds())
 }
+
+// starvationInsights explains long runnable waits, separating a full CPU
+// from waits that overlapped idle Ps when the busy-P timeline allows it
+func starvationInsights(rs *model.RunnableStarvation) []NarrativeInsight {
+	if rs == nil {
+		return []NarrativeInsight{{
+			Title:       "CPU Starvation",
+			Observation: "I noticed several goroutines are ready to run (Runnable) but are waiting too long for a CPU slot.",
+			Suggestion:  "This usually happens when GOMAXPROCS is too low or when a few goroutines are 'hogging' the CPU with tight loops. Check for non-preemptive code.",
+			DocLink:     "https://go.dev/doc/go1.14#runtime",
+			Severity:    "warning",
+		}}
+	}
+
+	var insights []NarrativeInsight
+	if rs.Saturated > 0 {
+		insights = append(insights, NarrativeInsight{
+			Title:       "CPU Saturation",
+			Observation: fmt.Sprintf("%d runnable waits of %s or more (%s in total) happened while every P was busy.", rs.Saturated, formatDuration(model.LongRunnableWait), formatDuration(rs.SaturatedTime)),
+			Suggestion:  "The CPU was simply full: there is more runnable work than GOMAXPROCS can run. Reduce the work (profile with 'go tool pprof'), bound the concurrency, or give the process more CPUs.",
+			DocLink:     "https://pkg.go.dev/runtime#GOMAXPROCS",
+			Severity:    "warning",
+		})
+	}
+	if w := rs.WorstAnomaly; w != nil {
+		insights = append(insights, NarrativeInsight{
+			Title: "Runnable While Ps Were Idle",
+			Observation: fmt.Sprintf("%d runnable waits (%s in total) overlapped idle Ps. The worst: goroutine %d waited %s at +%s with a P idle for %.0f%% of it.",
+				rs.Anomalous, formatDuration(rs.AnomalousTime), w.GoroutineID, formatDuration(w.Duration), formatDuration(w.Start), w.IdleShare*100),
+			Suggestion: "An idle P should steal runnable work within microseconds. Check that GOMAXPROCS doesn't exceed the CPUs the host or container quota really gives the process (idle Ps can't steal if their threads never get a CPU), and look for goroutines pinned with runtime.LockOSThread. If neither explains it, this is worth reporting to the Go issue tracker with the trace attached.",
+			DocLink:    "https://go.dev/issue/new",
+			Severity:   "critical",
+		})
+	}
+	return insights
+}
+
+// channelImbalanceInsight describes a fan-in or fan-out the other side of the
+// channel couldn't keep up with
+func channelImbalanceInsight(c model.ChannelImbalance) NarrativeInsight {
+	if c.Reason == model.BlockChannelSend {
+		return NarrativeInsight{
+			Title:       "Channel Fan-In Imbalance",
+			Observation: fmt.Sprintf("%d goroutines blocked sending in %s (%s in total) while %s couldn't keep up.", c.Blocked, c.Site, formatDuration(c.BlockedTime), plural(c.Peers, "receiver")),
+			Suggestion:  "The producers outpace the consumers of this channel. Add consumers (a worker pool reading the channel), buffer the channel to absorb bursts, or speed up the receiving loop.",
+			DocLink:     "https://go.dev/blog/pipelines",
+			Severity:    "warning",
+		}
+	}
+	return NarrativeInsight{
+		Title:       "Channel Fan-Out Imbalance",
+		Observation: fmt.Sprintf("%d goroutines blocked receiving in %s (%s in total) while %s fed them.", c.Blocked, c.Site, formatDuration(c.BlockedTime), plural(c.Peers, "sender")),
+		Suggestion:  "More consumers wait on this channel than its producers can feed. Add producers or speed them up, buffer the channel so bursts reach idle consumers, or shrink the consumer pool to what the producers sustain.",
+		DocLink:     "https://go.dev/blog/pipelines",
+		Severity:    "warning",
+	}
+}
+
+// plural formats a count with its noun, e.g. "1 receiver" or "3 receivers"
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// goroutineList renders up to n goroutine IDs as "#3, #7, #9 and 12 more"
+func goroutineList(ids []uint64, n int) string {
+	parts := make([]string, 0, n)
+	for _, id := range ids[:min(n, len(ids))] {
+		parts = append(parts, fmt.Sprintf("#%d", id))
+	}
+	list := strings.Join(parts, ", ")
+	if rest := len(ids) - len(parts); rest > 0 {
+		list += fmt.Sprintf(" and %d more", rest)
+	}
+	return list
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Hot-lock heuristic: many goroutines queueing far longer than the lock is
+// actually held points at the lock (or its cache line) being the hotspot
+const (
+	hotLockMinWaiters  = 4
+	hotLockMaxHold     = 10 * time.Microsecond
+	hotLockWaitToHold  = 50
+	minLockHandoffs    = 10
+	maxLockContentions = 5
+)
+
+// isLockWait reports whether ev is a wait to acquire a sync.Mutex/RWMutex;
+// the parser classifies these by stack, and the blocking frame catches any
+// left as "sync" (e.g. by a --reason-map override)
+func isLockWait(ev model.BlockingEvent) bool {
+	if ev.Reason == model.BlockMutexLock {
+		return true
+	}
+	op := ev.Op()
+	return strings.Contains(op, "Mutex).") && strings.Contains(op, "Lock")
+}
+
+// analyzeLockContention groups lock waits by call site and estimates how long
+// the lock is held. Under contention each release hands the lock to the next
+// waiter, so the median gap between consecutive hand-offs approximates the
+// hold time of the critical section.
+func (a *Analyzer) analyzeLockContention() {
+	type site struct {
+		waiters  map[uint64]bool
+		handoffs []time.Duration
+		waited   time.Duration
+	}
+	sites := make(map[string]*site)
+
+	for gid, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			name := ev.Site()
+			if !isLockWait(ev) || name == "" {
+				continue
+			}
+			s, ok := sites[name]
+			if !ok {
+				s = &site{waiters: make(map[uint64]bool)}
+				sites[name] = s
+			}
+			s.waiters[gid] = true
+			s.handoffs = append(s.handoffs, ev.EndTime)
+			s.waited += ev.Duration
+		}
+	}
+
+	var contentions []model.LockContention
+	for name, s := range sites {
+		if len(s.handoffs) < minLockHandoffs {
+			continue
+		}
+		sort.Slice(s.handoffs, func(i, j int) bool {
+			return s.handoffs[i] < s.handoffs[j]
+		})
+		gaps := make([]time.Duration, 0, len(s.handoffs)-1)
+		for i := 1; i < len(s.handoffs); i++ {
+			gaps = append(gaps, s.handoffs[i]-s.handoffs[i-1])
+		}
+		sort.Slice(gaps, func(i, j int) bool {
+			return gaps[i] < gaps[j]
+		})
+
+		contentions = append(contentions, model.LockContention{
+			Site:    name,
+			Waiters: len(s.waiters),
+			Waits:   len(s.handoffs),
+			AvgWait: s.waited / time.Duration(len(s.handoffs)),
+			Hold:    gaps[len(gaps)/2],
+		})
+	}
+
+	sort.Slice(contentions, func(i, j int) bool {
+		wi := contentions[i].AvgWait * time.Duration(contentions[i].Waits)
+		wj := contentions[j].AvgWait * time.Duration(contentions[j].Waits)
+		if wi != wj {
+			return wi > wj
+		}
+		return contentions[i].Site < contentions[j].Site
+	})
+	if len(contentions) > maxLockContentions {
+		contentions = contentions[:maxLockContentions]
+	}
+	a.summary.LockContention = contentions
+}
+
+// IsHotLock reports whether a lock's waits dwarf its hold time across many
+// goroutines, the signature of a tiny critical section on a hot lock
+func IsHotLock(c model.LockContention) bool {
+	if c.Waiters < hotLockMinWaiters || c.Hold > hotLockMaxHold {
+		return false
+	}
+	hold := max(c.Hold, time.Nanosecond)
+	return c.AvgWait/hold >= hotLockWaitToHold
+}
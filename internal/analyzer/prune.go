@@ -0,0 +1,34 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// KeepTop returns the k goroutines with the most blocked time and how many
+// were left out. It runs after Analyze, so the summary still covers every
+// goroutine; only per-goroutine output is bounded. The caller's map is
+// untouched; dropping it frees the pruned goroutines' events.
+func KeepTop(goroutines map[uint64]*model.GoroutineInfo, k int) (map[uint64]*model.GoroutineInfo, int) {
+	if k <= 0 || len(goroutines) <= k {
+		return goroutines, 0
+	}
+
+	all := make([]*model.GoroutineInfo, 0, len(goroutines))
+	for _, g := range goroutines {
+		all = append(all, g)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].TotalBlocked != all[j].TotalBlocked {
+			return all[i].TotalBlocked > all[j].TotalBlocked
+		}
+		return all[i].ID < all[j].ID
+	})
+
+	kept := make(map[uint64]*model.GoroutineInfo, k)
+	for _, g := range all[:k] {
+		kept[g.ID] = g
+	}
+	return kept, len(all) - k
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Recommendation is a suggested fix, ranked by estimated impact
+type Recommendation struct {
+	Action        string
+	Reason        model.BlockingReason
+	Site          string  // where most of the reason's blocking happens, "" if unknown
+	EstimatedGain float64 // percent of wall time that could be recovered
+	Effort        int     // 1 (easy) to 3 (hard)
+	ExampleID     uint64  // a goroutine that would benefit, 0 if none
+}
+
+// Where returns the action with the site it applies to, when known
+func (r Recommendation) Where() string {
+	if r.Site == "" {
+		return r.Action
+	}
+	return r.Action + " in " + r.Site
+}
+
+// fixCatalog describes the typical fix for each blocking reason and its effort
+var fixCatalog = map[model.BlockingReason]struct {
+	action string
+	effort int
+}{
+	model.BlockChannelRecv: {"Speed up producers or buffer the channels receivers wait on", 1},
+	model.BlockChannelSend: {"Add consumers or buffer the channels senders wait on", 1},
+	model.BlockMutexLock:   {"Reduce mutex hold time or shard the lock", 2},
+	model.BlockSync:        {"Shorten WaitGroup and Cond waits (stragglers, late signals)", 2},
+	model.BlockGC:          {"Cut the allocation rate (sync.Pool, fewer short-lived objects)", 2},
+	model.BlockSyscall:     {"Move blocking syscalls behind a bounded worker pool", 3},
+	model.BlockCgo:         {"Batch cgo calls and bound how many run at once", 3},
+	model.BlockGCAssist:    {"Allocate less on hot paths so goroutines stop paying for GC marking", 2},
+	model.BlockFinalizer:   {"Keep finalizers short and non-blocking, or replace them with explicit Close", 1},
+	model.BlockNetwork:     {"Batch or parallelize network calls and add timeouts", 2},
+	model.BlockSelect:      {"Review select loops that wait on slow cases", 2},
+	model.BlockSleep:       {"Remove or shorten deliberate sleeps and polling loops", 1},
+}
+
+// RankRecommendations orders fixes by estimated gain, the figure shown with
+// each, so the first is the dominant bottleneck's; between equal gains the
+// cheaper fix comes first. The gain comes from the speedup model: the wall time beyond the ideal (WallTime - IdealWallTime) is split
+// over blocking reasons by their share of blocked time. Without GOMAXPROCS
+// there is no ideal, and the gain falls back to the reason's share of all
+// goroutine time.
+func RankRecommendations(summary *model.Summary) []Recommendation {
+	total := summary.TotalBlockedTime + summary.TotalRuntime
+	if total <= 0 {
+		return nil
+	}
+	excess := summary.WallTime - summary.IdealWallTime
+	speedup := summary.IdealWallTime > 0 && excess > 0 && summary.TotalBlockedTime > 0
+
+	var recs []Recommendation
+	for reason, blocked := range summary.BlockingBreakdown {
+		fix, ok := fixCatalog[reason]
+		if !ok {
+			continue
+		}
+		gain := float64(blocked) / float64(total) * 100
+		if speedup {
+			saved := float64(excess) * float64(blocked) / float64(summary.TotalBlockedTime)
+			gain = saved / float64(summary.WallTime) * 100
+		}
+		if gain < 1 {
+			continue
+		}
+
+		rec := Recommendation{
+			Action:        fix.action,
+			Reason:        reason,
+			Site:          summary.ReasonSites[reason],
+			EstimatedGain: gain,
+			Effort:        fix.effort,
+		}
+		for _, g := range summary.TopBlocked {
+			if g.BlockingByReason[reason] > 0 {
+				rec.ExampleID = g.ID
+				break
+			}
+		}
+		recs = append(recs, rec)
+	}
+
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].EstimatedGain != recs[j].EstimatedGain {
+			return recs[i].EstimatedGain > recs[j].EstimatedGain
+		}
+		if recs[i].Effort != recs[j].Effort {
+			return recs[i].Effort < recs[j].Effort
+		}
+		return recs[i].Reason < recs[j].Reason
+	})
+
+	if len(recs) > 5 {
+		recs = recs[:5]
+	}
+	return recs
+}
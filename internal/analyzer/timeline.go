@@ -0,0 +1,598 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// blockingPhaseShare is the share of blocked time one third of the trace must
+// hold for blocking to count as concentrated there rather than uniform
+const blockingPhaseShare = 50
+
+// timelineBuckets is the number of windows the trace span is divided into
+const timelineBuckets = 100
+
+// computeGoroutineTimeline samples the live goroutine count across the trace
+func (a *Analyzer) computeGoroutineTimeline() {
+	if len(a.goroutines) == 0 || a.summary.WallTime <= 0 {
+		return
+	}
+
+	var start time.Duration
+	first := true
+	type delta struct {
+		at    time.Duration
+		count int
+	}
+	deltas := make([]delta, 0, 2*len(a.goroutines))
+	for _, g := range a.goroutines {
+		if first || g.CreatedAt < start {
+			start = g.CreatedAt
+		}
+		first = false
+		deltas = append(deltas, delta{at: g.CreatedAt, count: 1})
+		if g.TerminatedAt > 0 {
+			deltas = append(deltas, delta{at: g.TerminatedAt, count: -1})
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].at < deltas[j].at
+	})
+
+	bucket := a.summary.WallTime / timelineBuckets
+	if bucket <= 0 {
+		bucket = 1
+	}
+
+	timeline := make([]int, timelineBuckets)
+	live, idx := 0, 0
+	for i := range timeline {
+		end := start + bucket*time.Duration(i+1)
+		for idx < len(deltas) && deltas[idx].at <= end {
+			live += deltas[idx].count
+			idx++
+		}
+		timeline[i] = live
+	}
+
+	a.summary.GoroutineTimeline = timeline
+	a.summary.TimelineBucket = bucket
+}
+
+// detectOscillation looks for a sawtooth goroutine count by counting peaks that
+// rise well above the mean and recur at a roughly regular interval
+func (a *Analyzer) detectOscillation() {
+	timeline := a.summary.GoroutineTimeline
+	if len(timeline) < 3 {
+		return
+	}
+
+	minCount, maxCount, sum := timeline[0], timeline[0], 0
+	for _, c := range timeline {
+		sum += c
+		if c < minCount {
+			minCount = c
+		}
+		if c > maxCount {
+			maxCount = c
+		}
+	}
+	mean := float64(sum) / float64(len(timeline))
+	amplitude := maxCount - minCount
+	if amplitude < 10 {
+		return
+	}
+
+	// A peak is a local maximum in the upper half of the range; the count must
+	// drop back below the midline before the next peak is counted
+	midline := float64(minCount) + float64(amplitude)/2
+	var peaks []int
+	armed := true
+	for i := 1; i < len(timeline)-1; i++ {
+		c := float64(timeline[i])
+		if c < midline {
+			armed = true
+			continue
+		}
+		if armed && timeline[i] >= timeline[i-1] && timeline[i] >= timeline[i+1] && c > mean {
+			peaks = append(peaks, i)
+			armed = false
+		}
+	}
+	if len(peaks) < 3 {
+		return
+	}
+
+	intervals := make([]float64, 0, len(peaks)-1)
+	var total float64
+	for i := 1; i < len(peaks); i++ {
+		d := float64(peaks[i] - peaks[i-1])
+		intervals = append(intervals, d)
+		total += d
+	}
+	avg := total / float64(len(intervals))
+
+	var variance float64
+	for _, d := range intervals {
+		variance += (d - avg) * (d - avg)
+	}
+	stddev := math.Sqrt(variance / float64(len(intervals)))
+
+	// Irregular bursts are not an oscillation
+	if stddev/avg > 0.5 {
+		return
+	}
+
+	a.summary.Oscillation = &model.Oscillation{
+		Period:    time.Duration(avg * float64(a.summary.TimelineBucket)),
+		Amplitude: amplitude,
+		Cycles:    len(peaks),
+	}
+}
+
+// A cliff is a drop of at least cliffMinDrop goroutines, and of at least
+// cliffFraction of those alive, within cliffWindow timeline buckets
+const (
+	cliffMinDrop  = 50
+	cliffFraction = 0.5
+	cliffWindow   = 3
+)
+
+// detectCliffs finds sharp falls in the goroutine timeline and what the
+// goroutines that exited during each one were last woken from
+func (a *Analyzer) detectCliffs() {
+	timeline := a.summary.GoroutineTimeline
+	bucket := a.summary.TimelineBucket
+	for i := 1; i < len(timeline); i++ {
+		if timeline[i] >= timeline[i-1] {
+			continue
+		}
+		end := i
+		for end+1 < len(timeline) && end+1-i < cliffWindow && timeline[end+1] < timeline[end] {
+			end++
+		}
+		from, to := timeline[i-1], timeline[end]
+		if drop := from - to; drop < cliffMinDrop || float64(drop) < cliffFraction*float64(from) {
+			i = end
+			continue
+		}
+
+		cliff := model.GoroutineCliff{
+			At:       bucket * time.Duration(i),
+			Duration: bucket * time.Duration(end-i+1),
+			From:     from,
+			To:       to,
+		}
+		start := a.summary.TraceStart + cliff.At
+		cliff.UnblockReason, cliff.UnblockCount = a.lastUnblocks(start-bucket, start+cliff.Duration)
+		a.summary.Cliffs = append(a.summary.Cliffs, cliff)
+		i = end
+	}
+}
+
+// A goroutine count growing by at least growthMinGoroutines, and by
+// growthMinFactor over its fitted starting level, along a line that explains
+// at least growthMinR2 of its variance is a leak candidate. The last
+// growthTailShare of the timeline must still climb at growthTailSlope of the
+// overall rate, so a count that grew and then plateaued isn't reported.
+const (
+	growthMinGoroutines = 50
+	growthMinFactor     = 0.5
+	growthMinR2         = 0.8
+	growthTailShare     = 0.25
+	growthTailSlope     = 0.5
+)
+
+// detectGrowth fits a line to the goroutine timeline and reports sustained
+// growth with no plateau
+func (a *Analyzer) detectGrowth() {
+	timeline := a.summary.GoroutineTimeline
+	bucket := a.summary.TimelineBucket
+	if len(timeline) < 8 || bucket <= 0 {
+		return
+	}
+
+	slope, intercept, r2 := linearFit(timeline)
+	from := intercept
+	to := intercept + slope*float64(len(timeline)-1)
+	if slope <= 0 || r2 < growthMinR2 ||
+		to-from < growthMinGoroutines || to-from < growthMinFactor*max(from, 1) {
+		return
+	}
+	tail := timeline[len(timeline)-int(float64(len(timeline))*growthTailShare):]
+	if tailSlope, _, _ := linearFit(tail); tailSlope < growthTailSlope*slope {
+		return
+	}
+
+	a.summary.Growth = &model.GoroutineGrowth{
+		From:      int(math.Round(from)),
+		To:        int(math.Round(to)),
+		PerSecond: slope / bucket.Seconds(),
+		R2:        r2,
+	}
+}
+
+// linearFit is the least-squares line through counts indexed 0..n-1, with
+// the coefficient of determination of the fit
+func linearFit(counts []int) (slope, intercept, r2 float64) {
+	n := float64(len(counts))
+	var sumX, sumY float64
+	for i, c := range counts {
+		sumX += float64(i)
+		sumY += float64(c)
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var sxx, sxy, syy float64
+	for i, c := range counts {
+		dx, dy := float64(i)-meanX, float64(c)-meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
+	if sxx == 0 {
+		return 0, meanY, 0
+	}
+	slope = sxy / sxx
+	intercept = meanY - slope*meanX
+	if syy > 0 {
+		r2 = sxy * sxy / (sxx * syy)
+	}
+	return slope, intercept, r2
+}
+
+// lastUnblocks returns the most common reason goroutines exiting in
+// [from, to] were blocked on in their final block, if it ended in that window
+func (a *Analyzer) lastUnblocks(from, to time.Duration) (model.BlockingReason, int) {
+	counts := make(map[model.BlockingReason]int)
+	for _, g := range a.goroutines {
+		if g.TerminatedAt < from || g.TerminatedAt > to || len(g.BlockingEvents) == 0 {
+			continue
+		}
+		var last model.BlockingEvent
+		for _, ev := range g.BlockingEvents {
+			if ev.EndTime > last.EndTime {
+				last = ev
+			}
+		}
+		if last.EndTime >= from {
+			counts[last.Reason]++
+		}
+	}
+
+	var reason model.BlockingReason
+	n := 0
+	for r, c := range counts {
+		if c > n || (c == n && r < reason) {
+			reason, n = r, c
+		}
+	}
+	return reason, n
+}
+
+// oversubscribedFactor is how many runnable goroutines per P count as
+// oversubscription
+const oversubscribedFactor = 4
+
+// detectOversubscription compares the average runnable goroutine count to
+// GOMAXPROCS. With state intervals the backlog must also persist across at
+// least a quarter of the timeline windows, so a single burst doesn't count.
+func (a *Analyzer) detectOversubscription() {
+	if a.gomaxprocs <= 0 || a.summary.WallTime <= 0 {
+		return
+	}
+	threshold := float64(oversubscribedFactor * a.gomaxprocs)
+
+	var avg float64
+	if windows := ContentionTimeline(a.goroutines); len(windows) > 0 {
+		var sum float64
+		over := 0
+		for _, w := range windows {
+			sum += w.Runnable
+			if w.Runnable >= threshold {
+				over++
+			}
+		}
+		if over*4 < len(windows) {
+			return
+		}
+		avg = sum / float64(len(windows))
+	} else {
+		var runnable time.Duration
+		for _, g := range a.goroutines {
+			runnable += g.TotalRunnable
+		}
+		avg = float64(runnable) / float64(a.summary.WallTime)
+	}
+
+	if avg >= threshold {
+		a.summary.Oversubscription = &model.Oversubscription{
+			AvgRunnable: avg,
+			GOMAXPROCS:  a.gomaxprocs,
+		}
+	}
+}
+
+// DefaultConcurrencyRatio is how many times the goroutines wanting a CPU the
+// goroutine count must reach before a concurrency cap is recommended
+const DefaultConcurrencyRatio = 4
+
+// minCapGoroutines keeps small programs from getting a cap recommendation
+const minCapGoroutines = 16
+
+// capHeadroom sizes the recommended pool above the observed concurrency
+const capHeadroom = 1.25
+
+// capBacklogFactor is how many runnable goroutines per P make a backlog that
+// a smaller pool would relieve
+const capBacklogFactor = 2
+
+// externalWaits are blocking reasons spent waiting on the outside world or on
+// other goroutines; capping concurrency doesn't shorten them
+var externalWaits = []model.BlockingReason{
+	model.BlockNetwork, model.BlockSyscall, model.BlockSleep,
+	model.BlockChannelSend, model.BlockChannelRecv, model.BlockSelect,
+}
+
+// detectConcurrencyCap recommends a worker pool when goroutines queued for
+// the Ps: the runnable backlog must be well above GOMAXPROCS, outweigh time
+// spent in external waits, and the peak goroutine count must dwarf the
+// average number running or runnable at once
+func (a *Analyzer) detectConcurrencyCap() {
+	if a.concurrencyRatio <= 0 || a.summary.WallTime <= 0 || a.gomaxprocs <= 0 {
+		return
+	}
+
+	peak := a.summary.PeakGoroutines
+	for _, live := range a.summary.GoroutineTimeline {
+		if live > peak {
+			peak = live
+		}
+	}
+	if peak < minCapGoroutines {
+		return
+	}
+
+	wall := float64(a.summary.WallTime)
+	avgRunnable := float64(a.summary.TotalRunnable) / wall
+	backlog := float64(capBacklogFactor * a.gomaxprocs)
+	if avgRunnable < backlog && float64(a.peakRunnable) < backlog {
+		return
+	}
+
+	var external time.Duration
+	for _, r := range externalWaits {
+		external += a.summary.BlockingBreakdown[r]
+	}
+	if external >= a.summary.TotalRunnable {
+		return
+	}
+
+	concurrency := float64(a.summary.TotalRuntime+a.summary.TotalRunnable) / wall
+	if concurrency < 1 || float64(peak) < a.concurrencyRatio*concurrency {
+		return
+	}
+
+	a.summary.ConcurrencyCap = &model.ConcurrencyCap{
+		PeakGoroutines: peak,
+		Parallelism:    concurrency,
+		Recommended:    int(math.Ceil(concurrency * capHeadroom)),
+	}
+}
+
+// ContentionTimeline divides the trace into equal windows and reports how many
+// goroutines were running, runnable, and blocked in each. It requires state
+// intervals recorded by the parser.
+func ContentionTimeline(goroutines map[uint64]*model.GoroutineInfo) []model.ContentionWindow {
+	var start, end time.Duration
+	first := true
+	for _, g := range goroutines {
+		for _, iv := range g.Intervals {
+			if first || iv.Start < start {
+				start = iv.Start
+			}
+			if first || iv.End > end {
+				end = iv.End
+			}
+			first = false
+		}
+	}
+	if first || end <= start {
+		return nil
+	}
+
+	bucket := (end - start) / timelineBuckets
+	if bucket <= 0 {
+		bucket = 1
+	}
+	n := int((end-start)/bucket) + 1
+
+	windows := make([]model.ContentionWindow, n)
+	for i := range windows {
+		windows[i].Start = bucket * time.Duration(i)
+	}
+
+	for _, g := range goroutines {
+		for _, iv := range g.Intervals {
+			for i := int((iv.Start - start) / bucket); i < n; i++ {
+				wStart := start + bucket*time.Duration(i)
+				wEnd := wStart + bucket
+				if wStart >= iv.End {
+					break
+				}
+				overlap := min(iv.End, wEnd) - max(iv.Start, wStart)
+				if overlap <= 0 {
+					continue
+				}
+				share := float64(overlap) / float64(bucket)
+				switch iv.State {
+				case model.StateRunning:
+					windows[i].Running += share
+				case model.StateRunnable:
+					windows[i].Runnable += share
+				case model.StateBlocked:
+					windows[i].Blocked += share
+					windows[i].BlockedNs += overlap
+				}
+			}
+		}
+	}
+
+	return windows
+}
+
+// computeBlockingTiming finds where along the trace blocked time falls,
+// splitting each event across the thirds it overlaps
+func (a *Analyzer) computeBlockingTiming() {
+	span := a.traceEnd - a.traceStart
+	if span <= 0 {
+		return
+	}
+	third := span / 3
+
+	var total, weighted float64
+	var thirds [3]float64
+	for _, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			start := max(ev.StartTime, a.traceStart) - a.traceStart
+			end := min(ev.EndTime, a.traceEnd) - a.traceStart
+			if end <= start {
+				continue
+			}
+			d := float64(end - start)
+			total += d
+			weighted += d * float64(start+end) / 2
+			for i := range thirds {
+				lo, hi := third*time.Duration(i), third*time.Duration(i+1)
+				if i == 2 {
+					hi = span
+				}
+				if overlap := min(end, hi) - max(start, lo); overlap > 0 {
+					thirds[i] += float64(overlap)
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	t := &model.BlockingTiming{
+		Centroid: weighted / total / float64(span),
+		Phase:    "uniform",
+	}
+	peak := 0.0
+	for i, phase := range []string{"early", "middle", "late"} {
+		t.Thirds[i] = thirds[i] / total * 100
+		if t.Thirds[i] >= blockingPhaseShare && t.Thirds[i] > peak {
+			t.Phase, peak = phase, t.Thirds[i]
+		}
+	}
+	a.summary.BlockingTiming = t
+}
+
+// DescribeBlockingTiming answers "when did most blocking happen?" in one line
+func DescribeBlockingTiming(t *model.BlockingTiming) string {
+	switch t.Phase {
+	case "early":
+		return fmt.Sprintf("%.0f%% of blocking occurred in the first third of the trace — likely startup or warm-up cost.", t.Thirds[0])
+	case "middle":
+		return fmt.Sprintf("%.0f%% of blocking occurred in the middle third of the trace — likely a transient episode.", t.Thirds[1])
+	case "late":
+		return fmt.Sprintf("%.0f%% of blocking occurred in the last third of the trace — likely load-related degradation.", t.Thirds[2])
+	}
+	return fmt.Sprintf("Blocking was spread across the trace (%.0f/%.0f/%.0f%% by third) — steady-state contention.", t.Thirds[0], t.Thirds[1], t.Thirds[2])
+}
+
+// computeBlockedOverlap sweeps the sorted starts and ends of every blocking
+// event to find how many goroutines were blocked at each instant, then
+// integrates that count
+func (a *Analyzer) computeBlockedOverlap() {
+	n := 0
+	for _, g := range a.goroutines {
+		n += len(g.BlockingEvents)
+	}
+	starts := make([]time.Duration, 0, n)
+	ends := make([]time.Duration, 0, n)
+	for _, g := range a.goroutines {
+		for _, ev := range g.BlockingEvents {
+			if ev.Duration > 0 {
+				starts = append(starts, ev.StartTime)
+				ends = append(ends, ev.EndTime)
+			}
+		}
+	}
+	if len(starts) == 0 {
+		return
+	}
+	slices.Sort(starts)
+	slices.Sort(ends)
+
+	o := &model.BlockedOverlap{}
+	bucket := a.summary.TimelineBucket
+	windows := make([]float64, timelineBuckets)
+	var total, squared float64
+	blocked := 0
+	// Ends are taken before starts at the same instant, so back-to-back
+	// blocks don't count as overlapping
+	var prev time.Duration
+	for i, j := 0, 0; j < len(ends); {
+		var at time.Duration
+		var count int
+		if i < len(starts) && starts[i] < ends[j] {
+			at, count = starts[i], 1
+			i++
+		} else {
+			at, count = ends[j], -1
+			j++
+		}
+		if blocked > 0 {
+			span := at - prev
+			total += float64(span) * float64(blocked)
+			squared += float64(span) * float64(blocked*blocked)
+			if blocked >= 2 {
+				o.OverlapTime += span * time.Duration(blocked)
+			}
+			if bucket > 0 {
+				spreadOverWindows(windows, prev-a.traceStart, at-a.traceStart, bucket, blocked)
+			}
+		}
+		prev = at
+		blocked += count
+		if blocked > o.Peak {
+			o.Peak, o.PeakAt = blocked, at-a.traceStart
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	o.OverlapShare = float64(o.OverlapTime) / total
+	o.MeanConcurrent = squared / total
+	if bucket > 0 {
+		for i := range windows {
+			windows[i] /= float64(bucket)
+		}
+		o.Windows = windows
+	}
+	a.summary.BlockedOverlap = o
+}
+
+// spreadOverWindows adds count goroutines blocked over [start, end) to the
+// per-window goroutine-time totals
+func spreadOverWindows(windows []float64, start, end, bucket time.Duration, count int) {
+	for i := max(int(start/bucket), 0); i < len(windows); i++ {
+		lo, hi := bucket*time.Duration(i), bucket*time.Duration(i+1)
+		if lo >= end {
+			break
+		}
+		if overlap := min(end, hi) - max(start, lo); overlap > 0 {
+			windows[i] += float64(overlap) * float64(count)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// boundBy names what a program dominated by each blocking reason is bound by
+var boundBy = map[model.BlockingReason]string{
+	model.BlockChannelRecv: "channel-bound",
+	model.BlockChannelSend: "channel-bound",
+	model.BlockMutexLock:   "mutex-bound",
+	model.BlockSync:        "sync-bound",
+	model.BlockGC:          "GC-bound",
+	model.BlockSyscall:     "syscall-bound",
+	model.BlockCgo:         "cgo-bound",
+	model.BlockGCAssist:    "allocation-bound",
+	model.BlockFinalizer:   "finalizer-bound",
+	model.BlockNetwork:     "I/O-bound",
+	model.BlockSelect:      "select-bound",
+	model.BlockSleep:       "sleep-bound",
+}
+
+// Verdict condenses the analysis into one plain-English sentence: what the
+// program is bound by and the single biggest win
+func Verdict(summary *model.Summary) string {
+	if IsCPUBound(summary) && !summary.HasPerformanceIssues {
+		return "Verdict: your program is CPU-bound; profile it with go tool pprof rather than tuning the scheduler."
+	}
+	if !summary.HasPerformanceIssues || summary.TotalGoroutines == 0 {
+		return "Verdict: the scheduler looks healthy; no single bottleneck stands out."
+	}
+
+	var dominant model.BlockingReason
+	var pct float64
+	for reason, p := range summary.BlockingPercent {
+		if p > pct || (p == pct && reason < dominant) {
+			dominant, pct = reason, p
+		}
+	}
+	bound, ok := boundBy[dominant]
+	if !ok {
+		bound = "blocked mostly on " + dominant.String()
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Verdict: your program is %s (%.0f%% of blocked time)", bound, pct)
+
+	if recs := RankRecommendations(summary); len(recs) > 0 {
+		action := recs[0].Where()
+		fmt.Fprintf(&sb, "; the single biggest win is to %s%s", strings.ToLower(action[:1]), action[1:])
+	}
+	sb.WriteString(".")
+	return sb.String()
+}
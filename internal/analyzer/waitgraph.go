@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// maxWaitGraphEdges bounds the graph to the heaviest edges to keep it readable
+const maxWaitGraphEdges = 200
+
+// BuildWaitGraph builds a wait-for graph from which goroutine woke which.
+// Goroutines still blocked at trace end get a "current" edge to their most
+// frequent past waker; cycles among current edges indicate likely deadlocks.
+func BuildWaitGraph(goroutines map[uint64]*model.GoroutineInfo) *model.WaitGraph {
+	type key struct {
+		from, to uint64
+		reason   model.BlockingReason
+	}
+	edges := make(map[key]*model.WaitEdge)
+	wakers := make(map[uint64]map[uint64]int)
+
+	for gid, g := range goroutines {
+		for _, ev := range g.BlockingEvents {
+			if ev.WakerID == 0 {
+				continue
+			}
+			k := key{gid, ev.WakerID, ev.Reason}
+			e, ok := edges[k]
+			if !ok {
+				e = &model.WaitEdge{From: gid, To: ev.WakerID, Reason: ev.Reason}
+				edges[k] = e
+			}
+			e.Blocked += ev.Duration
+			e.Count++
+
+			if wakers[gid] == nil {
+				wakers[gid] = make(map[uint64]int)
+			}
+			wakers[gid][ev.WakerID]++
+		}
+	}
+
+	graph := &model.WaitGraph{}
+	current := make(map[uint64]uint64)
+	for gid, g := range goroutines {
+		if g.PendingBlock == nil || len(wakers[gid]) == 0 {
+			continue
+		}
+		var best uint64
+		bestCount := 0
+		for w, n := range wakers[gid] {
+			if n > bestCount || (n == bestCount && w < best) {
+				best, bestCount = w, n
+			}
+		}
+		current[gid] = best
+		graph.Edges = append(graph.Edges, model.WaitEdge{
+			From:    gid,
+			To:      best,
+			Reason:  g.PendingBlock.Reason,
+			Count:   1,
+			Current: true,
+		})
+	}
+
+	historical := make([]model.WaitEdge, 0, len(edges))
+	for _, e := range edges {
+		historical = append(historical, *e)
+	}
+	sort.Slice(historical, func(i, j int) bool {
+		if historical[i].Blocked != historical[j].Blocked {
+			return historical[i].Blocked > historical[j].Blocked
+		}
+		if historical[i].From != historical[j].From {
+			return historical[i].From < historical[j].From
+		}
+		return historical[i].To < historical[j].To
+	})
+	if len(historical) > maxWaitGraphEdges {
+		historical = historical[:maxWaitGraphEdges]
+	}
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		return graph.Edges[i].From < graph.Edges[j].From
+	})
+	graph.Edges = append(graph.Edges, historical...)
+	graph.Cycles = findWaitCycles(current)
+
+	return graph
+}
+
+// findWaitCycles follows each goroutine's single current wait edge and
+// reports every cycle once, starting from its lowest goroutine ID
+func findWaitCycles(next map[uint64]uint64) [][]uint64 {
+	starts := make([]uint64, 0, len(next))
+	for gid := range next {
+		starts = append(starts, gid)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+
+	visited := make(map[uint64]bool)
+	var cycles [][]uint64
+	for _, start := range starts {
+		if visited[start] {
+			continue
+		}
+		pos := make(map[uint64]int)
+		var path []uint64
+		cur := start
+		for {
+			if i, ok := pos[cur]; ok {
+				cycles = append(cycles, normalizeCycle(path[i:]))
+				break
+			}
+			if visited[cur] {
+				break
+			}
+			pos[cur] = len(path)
+			path = append(path, cur)
+			n, ok := next[cur]
+			if !ok {
+				break
+			}
+			cur = n
+		}
+		for _, gid := range path {
+			visited[gid] = true
+		}
+	}
+	return cycles
+}
+
+// normalizeCycle rotates a cycle so it starts at its lowest goroutine ID
+func normalizeCycle(cycle []uint64) []uint64 {
+	minIdx := 0
+	for i, gid := range cycle {
+		if gid < cycle[minIdx] {
+			minIdx = i
+		}
+	}
+	out := make([]uint64, 0, len(cycle))
+	out = append(out, cycle[minIdx:]...)
+	return append(out, cycle[:minIdx]...)
+}
@@ -1,6 +1,12 @@
 package model
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
 
 // GoroutineState represents the execution state of a goroutine
 type GoroutineState int
@@ -9,6 +15,7 @@ const (
 	StateRunning GoroutineState = iota
 	StateRunnable
 	StateBlocked
+	StateExited
 )
 
 func (s GoroutineState) String() string {
@@ -19,6 +26,8 @@ func (s GoroutineState) String() string {
 		return "runnable"
 	case StateBlocked:
 		return "blocked"
+	case StateExited:
+		return "exited"
 	default:
 		return "unknown"
 	}
@@ -38,6 +47,9 @@ const (
 	BlockSelect
 	BlockSleep
 	BlockSync
+	BlockCgo
+	BlockGCAssist
+	BlockFinalizer
 )
 
 func (r BlockingReason) String() string {
@@ -62,6 +74,95 @@ func (r BlockingReason) String() string {
 		return "sleep"
 	case BlockSync:
 		return "sync"
+	case BlockCgo:
+		return "cgo"
+	case BlockGCAssist:
+		return "GC assist"
+	case BlockFinalizer:
+		return "finalizer"
+	default:
+		return "unknown"
+	}
+}
+
+// reasonAliases maps short CLI names to the reasons they select
+var reasonAliases = map[string][]BlockingReason{
+	"chan":    {BlockChannelSend, BlockChannelRecv},
+	"channel": {BlockChannelSend, BlockChannelRecv},
+	"send":    {BlockChannelSend},
+	"recv":    {BlockChannelRecv},
+	"mutex":   {BlockMutexLock},
+	"lock":    {BlockMutexLock},
+	"net":     {BlockNetwork},
+	"timer":   {BlockSleep},
+	"assist":  {BlockGCAssist},
+}
+
+// AllBlockingReasons lists every known blocking reason in declaration order
+func AllBlockingReasons() []BlockingReason {
+	return []BlockingReason{
+		BlockNone, BlockChannelSend, BlockChannelRecv, BlockMutexLock, BlockSyscall,
+		BlockGC, BlockNetwork, BlockSelect, BlockSleep, BlockSync, BlockCgo,
+		BlockGCAssist, BlockFinalizer,
+	}
+}
+
+// ParseBlockingReasons parses a comma-separated list of reason names
+// (either String() forms like "channel receive" or aliases like "chan")
+func ParseBlockingReasons(list string) ([]BlockingReason, error) {
+	var reasons []BlockingReason
+	seen := make(map[BlockingReason]bool)
+	add := func(r BlockingReason) {
+		if !seen[r] {
+			seen[r] = true
+			reasons = append(reasons, r)
+		}
+	}
+
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if aliased, ok := reasonAliases[name]; ok {
+			for _, r := range aliased {
+				add(r)
+			}
+			continue
+		}
+		found := false
+		for _, r := range AllBlockingReasons() {
+			if r.String() == name || strings.ToLower(r.String()) == name {
+				add(r)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown blocking reason %q", name)
+		}
+	}
+	return reasons, nil
+}
+
+// SelectCase is the kind of case that ended a select block
+type SelectCase int
+
+const (
+	SelectUnknown SelectCase = iota
+	SelectRecv
+	SelectSend
+	SelectTimeout
+)
+
+func (c SelectCase) String() string {
+	switch c {
+	case SelectRecv:
+		return "receive"
+	case SelectSend:
+		return "send"
+	case SelectTimeout:
+		return "timeout"
 	default:
 		return "unknown"
 	}
@@ -73,7 +174,104 @@ type BlockingEvent struct {
 	EndTime   time.Duration
 	Duration  time.Duration
 	Reason    BlockingReason
-	Stack     string
+
+	// Stack is where the goroutine blocked; nil unless stack capture is enabled
+	Stack *EventStack
+
+	// WakerID is the goroutine that ended the block (e.g. the channel sender
+	// or lock releaser); 0 when woken by the runtime
+	WakerID uint64
+
+	// SelectCase is the inferred case that ended a select block
+	SelectCase SelectCase
+
+	// Frames identifies the blocking operation and its call site in the
+	// site table; see Op and Site
+	Frames SiteID
+
+	// WaitGroup marks a sync.WaitGroup.Wait, which shares the "sync" reason
+	WaitGroup bool
+}
+
+// Op is the innermost frame of the blocked goroutine, i.e. the blocking
+// operation itself (e.g. "sync.(*Mutex).Lock" or "syscall.read")
+func (e BlockingEvent) Op() string {
+	return e.Frames.Op()
+}
+
+// Site is the first frame outside the runtime and standard sync/syscall
+// packages, i.e. the code that issued the blocking operation
+func (e BlockingEvent) Site() string {
+	return e.Frames.Site()
+}
+
+// EventStack is the captured stack of a blocking event
+type EventStack struct {
+	// TopFrame is the first frame outside the runtime and standard blocking
+	// packages as "function file:line"
+	TopFrame string
+
+	// Frames holds every frame, innermost first, as "function file:line"
+	Frames []string
+}
+
+// SiteID indexes an (operation, call site) pair in the process-wide site
+// table, so the many events blocking at one place share a single copy of
+// its names; the zero ID has neither
+type SiteID uint32
+
+// siteFrames is an entry of the site table
+type siteFrames struct {
+	op, site string
+}
+
+// siteTable interns every (operation, call site) pair seen
+var siteTable = struct {
+	sync.RWMutex
+	ids     map[siteFrames]SiteID
+	entries []siteFrames
+}{
+	ids:     map[siteFrames]SiteID{{}: 0},
+	entries: []siteFrames{{}},
+}
+
+// InternSite returns the ID of the op and site pair, adding it to the site
+// table on first use
+func InternSite(op, site string) SiteID {
+	key := siteFrames{op, site}
+	siteTable.RLock()
+	id, ok := siteTable.ids[key]
+	siteTable.RUnlock()
+	if ok {
+		return id
+	}
+
+	siteTable.Lock()
+	defer siteTable.Unlock()
+	if id, ok := siteTable.ids[key]; ok {
+		return id
+	}
+	id = SiteID(len(siteTable.entries))
+	siteTable.entries = append(siteTable.entries, key)
+	siteTable.ids[key] = id
+	return id
+}
+
+// frames returns the table entry for id
+func (id SiteID) frames() siteFrames {
+	siteTable.RLock()
+	defer siteTable.RUnlock()
+	return siteTable.entries[id]
+}
+
+// Op returns the blocking operation of the site
+func (id SiteID) Op() string {
+	return id.frames().op
+}
+
+// Site returns the call site that issued the blocking operation
+func (id SiteID) Site() string {
+	return id.frames().site
 }
 
 // GoroutineInfo tracks the complete lifecycle and behavior of a goroutine
@@ -90,9 +288,100 @@ type GoroutineInfo struct {
 	// Aggregated blocking by reason
 	BlockingByReason map[BlockingReason]time.Duration
 
+	// Finalizer marks the runtime goroutine that runs finalizers and cleanups
+	Finalizer bool
+
+	// LongestBlock is the single longest contiguous block, the stall a user
+	// actually notices (zero if the goroutine never blocked)
+	LongestBlock BlockingEvent
+
+	// Thread is the OS thread (M) that last ran the goroutine; Migrations
+	// counts how many of its Schedules resumed on a different M
+	Thread     uint64
+	Schedules  int
+	Migrations int
+
 	// State machine tracking fields
 	LastStateChange time.Duration
 	PendingBlock    *BlockingEvent
+
+	// Label names the goroutine: a label the trace attaches to it, or else
+	// the first user region (runtime/trace.WithRegion) it entered
+	Label string
+
+	// Name is the goroutine's entry function, e.g. "main.worker"; empty if
+	// the trace never showed where it started
+	Name string
+
+	// CreationStack is the stack of the go statement that created the
+	// goroutine, innermost frame first, as "function file:line"; empty for
+	// goroutines that predate the trace
+	CreationStack []string
+
+	// CreationHash identifies where the goroutine was created (the go
+	// statement's call stack and the function it starts), so the same
+	// goroutine can be recognized across captures; empty for goroutines that
+	// predate the trace
+	CreationHash string
+
+	// Preexisting marks a goroutine that already existed when tracing started
+	// (e.g. every goroutine in a flight-recorder snapshot); its history begins
+	// mid-flight and CreatedAt is only the start of the trace
+	Preexisting bool
+
+	// DroppedEvents counts blocking events discarded by a per-goroutine
+	// retention cap; they still count towards TotalBlocked and BlockingByReason.
+	// DroppedByReason and DroppedSelects break the count down so event counts
+	// and averages can still cover every event.
+	DroppedEvents   int
+	DroppedByReason map[BlockingReason]int
+	DroppedSelects  map[SelectCase]int
+
+	// ReasonSequence is the reason of every block in order (only when the
+	// parser is configured to record it)
+	ReasonSequence []BlockingReason
+
+	// LongRunnable are the runnable waits of at least LongRunnableWait,
+	// recorded even without Intervals so starvation can be explained
+	LongRunnable []StateInterval
+
+	// Intervals holds every state interval (only when the parser is
+	// configured to record them); a goroutine alive at the end of the trace
+	// has its last one closed at the trace's final event
+	Intervals []StateInterval
+}
+
+// StateInterval is a contiguous period a goroutine spent in one state
+type StateInterval struct {
+	Start time.Duration
+	End   time.Duration
+	State GoroutineState
+}
+
+// LongRunnableWait is how long a goroutine must wait runnable before the wait
+// is recorded in LongRunnable; scheduling latency is normally microseconds
+const LongRunnableWait = time.Millisecond
+
+// ProcSample is the number of Ps running from At until the next sample
+type ProcSample struct {
+	At   time.Duration
+	Busy int
+}
+
+// ProcInfo is a P's utilization over the trace
+type ProcInfo struct {
+	ID          uint64
+	RunningTime time.Duration
+	IdleTime    time.Duration
+
+	// Schedules counts the goroutines switched onto the P
+	Schedules int
+
+	// State machine tracking fields: whether the P has been running or idle
+	// (neither while GOMAXPROCS leaves it unused) since LastStateChange
+	Running         bool
+	Idle            bool
+	LastStateChange time.Duration
 }
 
 // NewGoroutineInfo creates a new goroutine tracking structure
@@ -112,6 +401,57 @@ func (g *GoroutineInfo) AddBlockingEvent(event BlockingEvent) {
 	g.BlockingEvents = append(g.BlockingEvents, event)
 	g.TotalBlocked += event.Duration
 	g.BlockingByReason[event.Reason] += event.Duration
+	if event.Duration > g.LongestBlock.Duration {
+		g.LongestBlock = event
+	}
+}
+
+// DisplayName returns "#id", followed by the label when there is one
+func (g *GoroutineInfo) DisplayName() string {
+	switch {
+	case g.Label != "":
+		return fmt.Sprintf("#%d %s", g.ID, g.Label)
+	case g.Name != "":
+		return fmt.Sprintf("#%d %s", g.ID, ShortFuncName(g.Name))
+	}
+	return fmt.Sprintf("#%d", g.ID)
+}
+
+// ShortFuncName drops the import path from a function name, leaving the
+// package name: "github.com/x/y/pool.(*Pool).run" becomes "pool.(*Pool).run"
+func ShortFuncName(fn string) string {
+	paren := strings.IndexByte(fn, '(')
+	if paren < 0 {
+		paren = len(fn)
+	}
+	return fn[strings.LastIndexByte(fn[:paren], '/')+1:]
+}
+
+// Lifetime is how long the goroutine existed in the trace, from its creation
+// (or the start of the trace) to its exit; 0 if it was still alive at the end
+func (g *GoroutineInfo) Lifetime() time.Duration {
+	if g.TerminatedAt == 0 {
+		return 0
+	}
+	return g.TerminatedAt - g.CreatedAt
+}
+
+// StateAt looks up the goroutine's state at t in its recorded intervals; ok is
+// false before it was created, after it exited, or when no intervals were kept
+func (g *GoroutineInfo) StateAt(t time.Duration) (state GoroutineState, ok bool) {
+	if len(g.Intervals) == 0 || t < g.Intervals[0].Start {
+		return 0, false
+	}
+	i := sort.Search(len(g.Intervals), func(i int) bool {
+		return g.Intervals[i].End > t
+	})
+	if i < len(g.Intervals) {
+		return g.Intervals[i].State, true
+	}
+	if g.TerminatedAt > 0 && t >= g.TerminatedAt {
+		return 0, false
+	}
+	return g.CurrentState, true
 }
 
 // Summary holds aggregate metrics for the entire trace
@@ -119,20 +459,439 @@ type Summary struct {
 	TotalGoroutines int
 	PeakGoroutines  int
 
+	// Window is set when blocking was restricted to part of the trace
+	Window *TimeWindow
+
+	// AliveAtEnd counts the goroutines that hadn't exited when the trace
+	// ended, leaked ones among them
+	AliveAtEnd int
+
+	// GOMAXPROCS is the trace's GOMAXPROCS (0 if it didn't report it), and
+	// PeakRunnable the most goroutines that were runnable at once
+	GOMAXPROCS   int
+	PeakRunnable int
+
 	// Total time metrics
 	TotalBlockedTime time.Duration
 	TotalRuntime     time.Duration
+	TotalRunnable    time.Duration
+
+	// TraceStart is the timestamp of the earliest observed goroutine activity
+	TraceStart time.Duration
+
+	// Ideal vs actual wall time: ideal assumes zero blocking, i.e. total
+	// running time spread perfectly across GOMAXPROCS
+	WallTime      time.Duration
+	IdealWallTime time.Duration
 
 	// Blocking breakdown by reason
 	BlockingBreakdown map[BlockingReason]time.Duration
 	BlockingPercent   map[BlockingReason]float64
 
+	// How select blocks ended, by inferred case
+	SelectOutcomes map[SelectCase]int
+
+	// Number of blocking events and of distinct goroutines per reason
+	BlockingEventCount map[BlockingReason]int
+	GoroutinesByReason map[BlockingReason]int
+
+	// ReasonSites is the call site with the most blocked time for each
+	// reason whose events carry one
+	ReasonSites map[BlockingReason]string
+
 	// Top blocked goroutines
 	TopBlocked []*GoroutineInfo
 
+	// GC describes garbage collection frequency (nil if the caller didn't
+	// supply GC activity from the trace)
+	GC *GCActivity
+
+	// NoiseFloor is the minimum blocking event duration counted; NoiseEvents
+	// and NoiseTime are the shorter events that were discarded
+	NoiseFloor  time.Duration
+	NoiseEvents int
+	NoiseTime   time.Duration
+
+	// NeverBlocked counts goroutines that ran without ever blocking, and
+	// NeverBlockedRuntime is their combined running time
+	NeverBlocked        int
+	NeverBlockedRuntime time.Duration
+
+	// FinalizerRuntime is the time the finalizer goroutine spent running
+	// finalizers and cleanups
+	FinalizerRuntime time.Duration
+
+	// BlockingGini is the Gini coefficient of blocked time across goroutines
+	// that blocked: near 1 when one goroutine holds it all, near 0 when even
+	BlockingGini float64
+
+	// MigrationHeavy are the goroutines that most often resumed on a
+	// different OS thread, and TotalMigrations counts all such resumes
+	MigrationHeavy  []*GoroutineInfo
+	TotalMigrations int
+
+	// WorstStalls are the goroutines with the longest single block, worst first
+	WorstStalls []*GoroutineInfo
+
 	// Performance issues detected
 	HasPerformanceIssues bool
 	Issues               []string
+
+	// Incomplete is set when the trace was truncated and only partially parsed
+	Incomplete bool
+
+	// PrunedGoroutines is how many goroutines were left out of per-goroutine
+	// output by --keep-top; the summary was computed before and covers them
+	PrunedGoroutines int
+
+	// Clock converts trace timestamps to wall-clock time (nil if unavailable)
+	Clock *ClockReference
+
+	// Trace describes the capture the summary was computed from (nil if the
+	// caller didn't record it)
+	Trace *TraceInfo
+
+	// Longest individual blocking events across all goroutines (only
+	// populated when requested)
+	LongestBlocks []GoroutineBlock
+
+	// BlockingChains are the costliest causal chains of blocks across
+	// goroutines, if requested
+	BlockingChains []BlockingChain
+
+	// ReasonCycles are goroutines that alternate between two blocking
+	// reasons, if requested
+	ReasonCycles []ReasonCycle
+
+	// LockContention lists the most contended lock call sites
+	LockContention []LockContention
+
+	// ChannelImbalances are channel call sites where many blocked goroutines
+	// were served by few peers
+	ChannelImbalances []ChannelImbalance
+
+	// Goroutines waiting on a WaitGroup for nearly the whole trace
+	StuckWaitGroups []uint64
+
+	// LeakedGoroutines blocked well before the trace ended and never
+	// resumed, longest-blocked first
+	LeakedGoroutines []uint64
+
+	// Live goroutine count sampled at the end of each TimelineBucket window
+	GoroutineTimeline []int
+	TimelineBucket    time.Duration
+
+	// Oscillation describes a repeating spawn/drain pattern, if one was found
+	Oscillation *Oscillation
+
+	// Cliffs are sudden mass terminations found in the goroutine timeline
+	Cliffs []GoroutineCliff
+
+	// Growth is set when the goroutine count rose steadily over the whole
+	// trace without levelling off
+	Growth *GoroutineGrowth
+
+	// Oversubscription is set when runnable goroutines persistently
+	// outnumber GOMAXPROCS
+	Oversubscription *Oversubscription
+
+	// ConcurrencyCap is set when far more goroutines existed than were ever
+	// usefully running at once
+	ConcurrencyCap *ConcurrencyCap
+
+	// RunnableStarvation splits long runnable waits by whether every P was
+	// busy at the time (nil without proc data or long waits)
+	RunnableStarvation *RunnableStarvation
+
+	// BlockedOverlap measures how much blocking happened simultaneously
+	// (nil if nothing blocked)
+	BlockedOverlap *BlockedOverlap
+
+	// CriticalPath splits blocked time into what gated the root goroutine
+	// (usually main) and background blocking (nil without a root)
+	CriticalPath *CriticalPath
+
+	// BlockingTiming says when in the trace blocking happened (nil if
+	// nothing blocked)
+	BlockingTiming *BlockingTiming
+
+	// Churn is the rate of runnable→running switches, a proxy for context
+	// switching overhead (nil if nothing was scheduled)
+	Churn *SchedulerChurn
+}
+
+// StateSplit returns the percentage of all goroutine time spent running,
+// runnable and blocked; all zero if nothing was recorded
+func (s *Summary) StateSplit() (running, runnable, blocked float64) {
+	total := s.TotalRuntime + s.TotalRunnable + s.TotalBlockedTime
+	if total <= 0 {
+		return 0, 0, 0
+	}
+	pct := func(d time.Duration) float64 {
+		return float64(d) / float64(total) * 100
+	}
+	return pct(s.TotalRuntime), pct(s.TotalRunnable), pct(s.TotalBlockedTime)
+}
+
+// RunnableStarvation classifies runnable waits of at least LongRunnableWait:
+// Saturated ones happened while all Ps were busy, so the CPU was genuinely
+// full; Anomalous ones had an idle P for most of the wait, which the
+// scheduler should not allow
+type RunnableStarvation struct {
+	Saturated     int
+	SaturatedTime time.Duration
+	Anomalous     int
+	AnomalousTime time.Duration
+
+	// WorstAnomaly is the longest anomalous wait; IdleShare is the fraction
+	// of it during which at least one P was idle
+	WorstAnomaly *RunnableWait
+}
+
+// RunnableWait is one goroutine's wait in the run queue; Start is an offset
+// from the trace start
+type RunnableWait struct {
+	GoroutineID uint64
+	Start       time.Duration
+	Duration    time.Duration
+	IdleShare   float64
+}
+
+// BlockedOverlap tells "everything stalled at once" apart from blocks spread
+// out in time, which summed blocked time can't. OverlapTime is the blocked
+// goroutine-time spent while at least one other goroutine was also blocked;
+// MeanConcurrent is the number of goroutines blocked at once as seen by an
+// average blocked second; Windows is the average count per TimelineBucket.
+type BlockedOverlap struct {
+	OverlapTime    time.Duration
+	OverlapShare   float64 // OverlapTime as a fraction of all blocked time
+	MeanConcurrent float64
+	Peak           int
+	PeakAt         time.Duration // offset from the trace start
+	Windows        []float64
+}
+
+// CriticalPath attributes the root goroutine's blocked time to the
+// goroutines and reasons that actually held it up: while the root waits on a
+// waker, the waker's own blocking in that window is charged to the waker,
+// recursively. Blocking elsewhere didn't delay the root and is OffPath.
+type CriticalPath struct {
+	Root       uint64
+	Blocked    time.Duration
+	ByReason   map[BlockingReason]time.Duration
+	Goroutines []GoroutineShare // most blocked first
+	OffPath    time.Duration
+}
+
+// GoroutineShare is one goroutine's part of an attributed duration
+type GoroutineShare struct {
+	ID       uint64
+	Duration time.Duration
+}
+
+// BlockingChain is a causal sequence of blocks: each goroutine waited on the
+// next, which was itself blocked at the time. The last goroutine was not
+// blocked when it released the chain and is the root cause.
+type BlockingChain struct {
+	Goroutines []uint64
+	// Reasons[i] is why Goroutines[i] was blocked; the root cause only has an
+	// entry when it was itself waiting on the runtime (e.g. a sleep)
+	Reasons      []BlockingReason
+	Occurrences  int
+	TotalLatency time.Duration
+	MaxLatency   time.Duration
+}
+
+// RootCause returns the goroutine at the tail of the chain
+func (c BlockingChain) RootCause() uint64 {
+	return c.Goroutines[len(c.Goroutines)-1]
+}
+
+// ReasonCycle is a goroutine whose blocks mostly alternate between two
+// reasons, e.g. syscall → channel receive in an I/O and dispatch loop
+type ReasonCycle struct {
+	GoroutineID  uint64
+	Label        string
+	A, B         BlockingReason
+	Alternations int
+	// Share is the fraction of the goroutine's reason changes that are A↔B
+	Share float64
+}
+
+// LockContention summarizes waits on one lock call site. Hold is an estimate
+// of the critical section length from the spacing of lock hand-offs.
+type LockContention struct {
+	Site    string
+	Waiters int
+	Waits   int
+	AvgWait time.Duration
+	Hold    time.Duration
+}
+
+// ChannelImbalance is a channel operation site where Blocked goroutines
+// waited on only Peers goroutines on the other side: senders blocked on few
+// receivers (fan-in) for BlockChannelSend, receivers starved by few senders
+// (fan-out) for BlockChannelRecv
+type ChannelImbalance struct {
+	Site        string
+	Reason      BlockingReason
+	Blocked     int
+	Peers       int
+	BlockedTime time.Duration
+}
+
+// GoroutineBlock is a blocking event attributed to its goroutine
+type GoroutineBlock struct {
+	GoroutineID uint64
+	Event       BlockingEvent
+}
+
+// WaitEdge records that a goroutine waited on something another goroutine
+// fed or released
+type WaitEdge struct {
+	From    uint64 // waiting goroutine
+	To      uint64 // goroutine that woke it
+	Reason  BlockingReason
+	Blocked time.Duration
+	Count   int
+
+	// Current is set when From was still blocked at trace end and To is its
+	// most frequent past waker, i.e. the edge describes an unresolved wait
+	Current bool
+}
+
+// WaitGraph is a wait-for graph between goroutines
+type WaitGraph struct {
+	Edges  []WaitEdge
+	Cycles [][]uint64
+}
+
+// GoroutineCliff is a sudden drop in the live goroutine count, e.g. a
+// cancellation storm, a panic cascade or a shutdown
+type GoroutineCliff struct {
+	At       time.Duration // when the drop starts, relative to trace start
+	Duration time.Duration
+	From     int
+	To       int
+
+	// UnblockReason is what most of the exiting goroutines were last blocked
+	// on right before they exited, and UnblockCount how many of them it was
+	UnblockReason BlockingReason
+	UnblockCount  int
+}
+
+// GoroutineGrowth is a linear fit of the live goroutine count: From and To
+// are the fitted counts at the start and end of the trace, and R2 how well a
+// straight line explains the timeline (1 = perfectly)
+type GoroutineGrowth struct {
+	From      int
+	To        int
+	PerSecond float64
+	R2        float64
+}
+
+// Oscillation describes periodic bursts of goroutine creation that then drain
+type Oscillation struct {
+	Period    time.Duration
+	Amplitude int
+	Cycles    int
+}
+
+// Oversubscription describes a sustained runnable backlog relative to the
+// number of Ps available to run it
+type Oversubscription struct {
+	AvgRunnable float64
+	GOMAXPROCS  int
+}
+
+// TimeWindow is the part of the trace blocking was aggregated over, as
+// offsets from its start; a zero Until means the end of the trace
+type TimeWindow struct {
+	Since time.Duration
+	Until time.Duration
+}
+
+// BlockingTiming places blocked time along the trace: Centroid is the
+// duration-weighted center of all blocking as a fraction of the trace (0 =
+// start, 1 = end), and Thirds the percentage of blocked time falling in each
+// third of it
+type BlockingTiming struct {
+	Centroid float64
+	Thirds   [3]float64
+	Phase    string // "early", "middle", "late" or "uniform"
+}
+
+// SchedulerChurn measures how often goroutines are switched onto a P against
+// how long each then runs; many switches with tiny run slices mean the
+// scheduler spends its time switching rather than executing
+type SchedulerChurn struct {
+	Switches      int
+	PerSecond     float64       // switches per second of trace wall time
+	PerProcSecond float64       // PerSecond divided by GOMAXPROCS (0 if unknown)
+	AvgRunSlice   time.Duration // average running time per switch
+	Thrashing     bool
+}
+
+// TraceInfo describes a trace capture; the capture duration is the summary's
+// WallTime
+type TraceInfo struct {
+	File          string
+	Size          int64
+	GoVersion     string // e.g. "go1.23"
+	GOMAXPROCS    int    // 0 if the trace didn't report it
+	Events        int64
+	SkippedEvents int
+}
+
+// GCActivity summarizes the garbage collection cycles in a trace; frequent
+// cycles point at allocation pressure even when each pause is short
+type GCActivity struct {
+	Cycles     int
+	PerSecond  float64 // cycles per second of trace wall time
+	Pauses     int     // GC stop-the-world pauses
+	TotalPause time.Duration
+	AvgPause   time.Duration
+	MaxPause   time.Duration
+}
+
+// ConcurrencyCap compares the goroutine count with how many goroutines wanted
+// a CPU and suggests a worker pool size
+type ConcurrencyCap struct {
+	PeakGoroutines int
+	Parallelism    float64 // average number of goroutines running or runnable at once
+	Recommended    int
+}
+
+// ContentionWindow is one time bucket of scheduler state, with goroutine
+// counts expressed as the time-weighted average over the window
+type ContentionWindow struct {
+	Start     time.Duration
+	Running   float64
+	Runnable  float64
+	Blocked   float64
+	BlockedNs time.Duration
+}
+
+// TimestampPrecision describes how far trace timestamps can be trusted. The
+// runtime stamps events in clock ticks, and the reader nudges an event 1ns
+// past its predecessor when batches flushed by different threads disagree
+// on the time, so intervals near those points are only approximate.
+type TimestampPrecision struct {
+	Resolution  time.Duration // granularity of the trace clock
+	Adjusted    int           // events moved forward to keep timestamps ordered
+	Generations int           // trace generations, each flushed as separate batches
+}
+
+// ClockReference pairs a trace timestamp with the wall-clock time it was
+// taken at, allowing trace-relative timestamps to be converted to real time
+type ClockReference struct {
+	Trace time.Duration
+	Wall  time.Time
+}
+
+// WallTime converts a trace timestamp into wall-clock time
+func (c ClockReference) WallTime(ts time.Duration) time.Time {
+	return c.Wall.Add(ts - c.Trace)
 }
 
 // StateTransition represents a change in goroutine state
@@ -0,0 +1,27 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+)
+
+// AssertionJSON is one rule's metric, threshold and verdict
+type AssertionJSON struct {
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold"`
+	Pass      bool    `json:"pass"`
+}
+
+// WriteAssertions writes the assertions as a JSON object keyed by rule name,
+// e.g. {"mutex_pct": {"value": 34.2, "threshold": 30, "pass": false}}
+func WriteAssertions(w io.Writer, assertions []analyzer.Assertion) error {
+	out := make(map[string]AssertionJSON, len(assertions))
+	for _, a := range assertions {
+		out[a.Name] = AssertionJSON{Value: a.Value, Threshold: a.Threshold, Pass: a.Pass}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
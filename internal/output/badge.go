@@ -0,0 +1,67 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Badge is a shields.io endpoint payload
+// (https://shields.io/badges/endpoint-badge)
+type Badge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// NewBadge grades the summary by its detected issues: none is healthy, one or
+// two is a warning, more is critical
+func NewBadge(summary *model.Summary) Badge {
+	b := Badge{SchemaVersion: 1, Label: "scheduler"}
+	switch n := len(summary.Issues); {
+	case !summary.HasPerformanceIssues || n == 0:
+		b.Message, b.Color = "healthy", "green"
+	case n == 1:
+		b.Message, b.Color = "1 issue", "yellow"
+	case n == 2:
+		b.Message, b.Color = "2 issues", "orange"
+	default:
+		b.Message, b.Color = fmt.Sprintf("%d issues", n), "red"
+	}
+	return b
+}
+
+// WriteBadge writes the shields.io badge JSON for the summary
+func WriteBadge(w io.Writer, summary *model.Summary) error {
+	return json.NewEncoder(w).Encode(NewBadge(summary))
+}
+
+// BadgeFormatter writes the shields.io badge JSON for a summary
+type BadgeFormatter struct {
+	writer io.Writer
+}
+
+func init() {
+	RegisterExport("badge", func(w io.Writer, _ Options) Formatter {
+		return &BadgeFormatter{writer: w}
+	})
+}
+
+// FormatSummary writes the badge graded from the summary
+func (f *BadgeFormatter) FormatSummary(summary *model.Summary) error {
+	return WriteBadge(f.writer, summary)
+}
+
+// FormatGoroutineDetail is not supported: a badge grades a whole analysis
+func (f *BadgeFormatter) FormatGoroutineDetail(*model.GoroutineInfo) error {
+	return exportOnly("badge")
+}
+
+// FormatInsights is not supported: a badge grades a whole analysis
+func (f *BadgeFormatter) FormatInsights([]analyzer.NarrativeInsight) error {
+	return exportOnly("badge")
+}
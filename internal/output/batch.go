@@ -0,0 +1,91 @@
+package output
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// BatchEntry is the analysis of one trace in a batch run; Summary is nil when
+// the trace could not be analyzed
+type BatchEntry struct {
+	File    string
+	Summary *model.Summary
+	Err     error
+}
+
+// BatchEntryJSON represents a batch entry in JSON
+type BatchEntryJSON struct {
+	File         string   `json:"file"`
+	Error        string   `json:"error,omitempty"`
+	Goroutines   int      `json:"total_goroutines,omitempty"`
+	TotalBlocked string   `json:"total_blocked_time,omitempty"`
+	WallTime     string   `json:"wall_time,omitempty"`
+	TopReason    string   `json:"top_reason,omitempty"`
+	Issues       []string `json:"issues,omitempty"`
+}
+
+// FormatBatch outputs one ranked row per trace
+func (f *TextFormatter) FormatBatch(entries []BatchEntry) error {
+	fmt.Fprintln(f.writer, titleStyle.Render(fmt.Sprintf(" BATCH: %d TRACES ", len(entries))))
+
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-28s %-8s %-12s %-16s %s", "TRACE", "ISSUES", "BLOCKED", "TOP REASON", "GOROUTINES")))
+	for _, e := range entries {
+		name := filepath.Base(e.File)
+		if e.Summary == nil {
+			rows = append(rows, fmt.Sprintf("%-28s %s", name, dangerStyle.Render(e.Err.Error())))
+			continue
+		}
+		issues := successStyle.Render(fmt.Sprintf("%-8d", 0))
+		if n := len(e.Summary.Issues); n > 0 {
+			issues = dangerStyle.Render(fmt.Sprintf("%-8d", n))
+		}
+		rows = append(rows, fmt.Sprintf("%-28s %s %-12s %-16s %d",
+			name,
+			issues,
+			f.duration(e.Summary.TotalBlockedTime),
+			topReason(e.Summary),
+			e.Summary.TotalGoroutines))
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+	return nil
+}
+
+// FormatBatch outputs the batch entries as a JSON array
+func (f *JSONFormatter) FormatBatch(entries []BatchEntry) error {
+	out := make([]BatchEntryJSON, 0, len(entries))
+	for _, e := range entries {
+		ej := BatchEntryJSON{File: e.File}
+		if e.Summary == nil {
+			ej.Error = e.Err.Error()
+		} else {
+			ej.Goroutines = e.Summary.TotalGoroutines
+			ej.TotalBlocked = formatDurationJSON(e.Summary.TotalBlockedTime)
+			ej.WallTime = formatDurationJSON(e.Summary.WallTime)
+			ej.TopReason = topReason(e.Summary)
+			ej.Issues = e.Summary.Issues
+		}
+		out = append(out, ej)
+	}
+
+	return f.encoder().Encode(out)
+}
+
+// topReason returns the blocking reason with the most total time
+func topReason(summary *model.Summary) string {
+	var top model.BlockingReason
+	var topDur int64 = -1
+	for reason, d := range summary.BlockingBreakdown {
+		if int64(d) > topDur || (int64(d) == topDur && reason < top) {
+			top, topDur = reason, int64(d)
+		}
+	}
+	if topDur <= 0 {
+		return "-"
+	}
+	return top.String()
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// ChromeTraceFormatter renders each goroutine's blocking events as Chrome
+// Trace Event JSON for Perfetto. Unlike PerfettoFormatter it needs no state
+// intervals: it draws only the blocks, one track per goroutine.
+type ChromeTraceFormatter struct {
+	writer io.Writer
+}
+
+// NewChromeTraceFormatter creates a Chrome trace formatter
+func NewChromeTraceFormatter(w io.Writer) *ChromeTraceFormatter {
+	return &ChromeTraceFormatter{writer: w}
+}
+
+// pidBlocking is the single process all goroutine tracks belong to
+const pidBlocking = 1
+
+// FormatTimeline outputs one duration event per blocking event, named after
+// its reason, with the goroutine ID as tid. Times are relative to traceStart.
+func (f *ChromeTraceFormatter) FormatTimeline(goroutines map[uint64]*model.GoroutineInfo, traceStart time.Duration) error {
+	ids := make([]uint64, 0, len(goroutines))
+	for id, g := range goroutines {
+		if len(g.BlockingEvents) > 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	events := []traceEvent{{
+		Name:  "process_name",
+		Phase: "M",
+		PID:   pidBlocking,
+		Args:  map[string]string{"name": "blocking"},
+	}}
+	for _, id := range ids {
+		g := goroutines[id]
+		events = append(events, traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   pidBlocking,
+			TID:   g.ID,
+			Args:  map[string]string{"name": g.DisplayName()},
+		})
+		for _, ev := range g.BlockingEvents {
+			args := make(map[string]string)
+			if ev.Op() != "" {
+				args["op"] = ev.Op()
+			}
+			if ev.Site() != "" {
+				args["site"] = ev.Site()
+			}
+			events = append(events, traceEvent{
+				Name:  ev.Reason.String(),
+				Phase: "X",
+				TS:    microseconds(ev.StartTime - traceStart),
+				Dur:   microseconds(ev.Duration),
+				PID:   pidBlocking,
+				TID:   g.ID,
+				Args:  args,
+			})
+		}
+	}
+
+	return json.NewEncoder(f.writer).Encode(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{events})
+}
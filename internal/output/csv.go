@@ -0,0 +1,142 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// WriteContentionCSV writes scheduler state per time window as CSV
+func WriteContentionCSV(w io.Writer, windows []model.ContentionWindow) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"time_bucket", "running_goroutines", "runnable_goroutines", "blocked_goroutines", "blocked_ns"}); err != nil {
+		return err
+	}
+
+	for _, win := range windows {
+		record := []string{
+			strconv.FormatInt(win.Start.Nanoseconds(), 10),
+			fmt.Sprintf("%.2f", win.Running),
+			fmt.Sprintf("%.2f", win.Runnable),
+			fmt.Sprintf("%.2f", win.Blocked),
+			strconv.FormatInt(win.BlockedNs.Nanoseconds(), 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteScatterCSV writes per-goroutine running, runnable and blocked time as
+// CSV, ordered by goroutine ID, for CPU-bound vs starved scatter plots
+func WriteScatterCSV(w io.Writer, goroutines map[uint64]*model.GoroutineInfo) error {
+	ids := make([]uint64, 0, len(goroutines))
+	for id := range goroutines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"id", "running_ns", "runnable_ns", "blocked_ns"}); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		g := goroutines[id]
+		record := []string{
+			strconv.FormatUint(g.ID, 10),
+			strconv.FormatInt(g.TotalRuntime.Nanoseconds(), 10),
+			strconv.FormatInt(g.TotalRunnable.Nanoseconds(), 10),
+			strconv.FormatInt(g.TotalBlocked.Nanoseconds(), 10),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVFormatter writes one row per goroutine, most blocked first, for
+// spreadsheets and offline processing; durations are raw nanoseconds
+type CSVFormatter struct {
+	writer     io.Writer
+	goroutines map[uint64]*model.GoroutineInfo
+}
+
+func init() {
+	RegisterExport("csv", func(w io.Writer, _ Options) Formatter {
+		return NewCSVFormatter(w)
+	})
+}
+
+// NewCSVFormatter creates a CSV formatter
+func NewCSVFormatter(w io.Writer) *CSVFormatter {
+	return &CSVFormatter{writer: w}
+}
+
+// SetGoroutines provides every analyzed goroutine; without them only the
+// summary's top blocked goroutines are written
+func (f *CSVFormatter) SetGoroutines(goroutines map[uint64]*model.GoroutineInfo) {
+	f.goroutines = goroutines
+}
+
+// FormatSummary writes the goroutine table with a header row
+func (f *CSVFormatter) FormatSummary(summary *model.Summary) error {
+	rows := summary.TopBlocked
+	if f.goroutines != nil {
+		rows = make([]*model.GoroutineInfo, 0, len(f.goroutines))
+		for _, g := range f.goroutines {
+			rows = append(rows, g)
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].TotalBlocked != rows[j].TotalBlocked {
+				return rows[i].TotalBlocked > rows[j].TotalBlocked
+			}
+			return rows[i].ID < rows[j].ID
+		})
+	}
+
+	cw := csv.NewWriter(f.writer)
+	if err := cw.Write([]string{"id", "entry_function", "total_blocked_ns", "total_runtime_ns",
+		"total_runnable_ns", "primary_reason", "blocking_event_count"}); err != nil {
+		return err
+	}
+
+	for _, g := range rows {
+		record := []string{
+			strconv.FormatUint(g.ID, 10),
+			g.Name,
+			strconv.FormatInt(g.TotalBlocked.Nanoseconds(), 10),
+			strconv.FormatInt(g.TotalRuntime.Nanoseconds(), 10),
+			strconv.FormatInt(g.TotalRunnable.Nanoseconds(), 10),
+			getPrimaryBlockingReason(g).String(),
+			strconv.Itoa(len(g.BlockingEvents) + g.DroppedEvents),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// FormatGoroutineDetail is not supported: the table covers every goroutine
+func (f *CSVFormatter) FormatGoroutineDetail(*model.GoroutineInfo) error {
+	return exportOnly("csv")
+}
+
+// FormatInsights is not supported: the table covers every goroutine
+func (f *CSVFormatter) FormatInsights([]analyzer.NarrativeInsight) error {
+	return exportOnly("csv")
+}
@@ -1,9 +1,11 @@
 package output
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -23,6 +25,7 @@ type dashboardState int
 const (
 	StateHome dashboardState = iota
 	StateLiveInput
+	StateAuthInput
 	StateManualFile
 	StateExploring
 	StateError
@@ -35,6 +38,11 @@ type DashboardModel struct {
 	err            error
 	selectedOption int
 	liveURL        string
+	headers        http.Header
+
+	// Analyses completed this session and the verdict of the latest one
+	analyses    int
+	lastVerdict string
 }
 
 func NewDashboardModel() DashboardModel {
@@ -48,9 +56,20 @@ func NewDashboardModel() DashboardModel {
 		state:     StateHome,
 		textInput: ti,
 		liveURL:   "http://localhost:6060/debug/pprof/trace?seconds=5",
+		headers:   make(http.Header),
 	}
 }
 
+// SessionSummary reports how many traces were analyzed and the last verdict
+func (m DashboardModel) SessionSummary() (analyses int, lastVerdict string) {
+	return m.analyses, m.lastVerdict
+}
+
+// SetHeaders sets extra HTTP headers (e.g. Authorization) sent with live captures
+func (m *DashboardModel) SetHeaders(h http.Header) {
+	m.headers = h.Clone()
+}
+
 func (m DashboardModel) Init() tea.Cmd {
 	return textinput.Blink
 }
@@ -60,18 +79,21 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Global Quit handler (unless in input mode or explorer)
-		if m.state == StateHome && (msg.String() == "q" || msg.String() == "ctrl+c") {
+		// Ctrl+C quits from anywhere, including text inputs and a running capture
+		if msg.String() == "ctrl+c" {
 			return m, tea.Quit
 		}
-		if (m.state == StateExploring) && msg.String() == "ctrl+c" {
+		if m.state == StateHome && msg.String() == "q" {
 			return m, tea.Quit
 		}
 
 	// Handle Analysis Result
 	case AnalysisResultMsg:
 		m.explorer = NewExplorerModel(msg.Summary, msg.Goroutines)
+		m.explorer.SetReanalyzer(msg.Reanalyze, AnalysisToggles{})
 		m.state = StateExploring
+		m.analyses++
+		m.lastVerdict = analyzer.Verdict(msg.Summary)
 		return m, nil
 
 	case AnalysisErrorMsg:
@@ -112,8 +134,32 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					url = m.textInput.Placeholder
 				}
 				m.liveURL = url
+				// Ask for an optional Authorization header before capturing
+				m.state = StateAuthInput
+				m.textInput.Placeholder = "Bearer <token> (optional)"
+				m.textInput.SetValue(m.headers.Get("Authorization"))
+				return m, cmd
+			}
+			if msg.String() == "esc" {
+				m.state = StateHome
+			}
+		}
+
+	case StateAuthInput:
+		var tiCmd tea.Cmd
+		m.textInput, tiCmd = m.textInput.Update(msg)
+		cmd = tiCmd
+
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			if msg.String() == "enter" {
+				if auth := strings.TrimSpace(m.textInput.Value()); auth != "" {
+					m.headers.Set("Authorization", auth)
+				} else {
+					m.headers.Del("Authorization")
+				}
 				// Start the capture/analysis loop
-				return m, runLiveCapture(url)
+				return m, runLiveCapture(m.liveURL, m.headers)
 			}
 			if msg.String() == "esc" {
 				m.state = StateHome
@@ -121,14 +167,16 @@ func (m DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case StateExploring:
-		// Forward messages to the explorer sub-model
+		// Forward messages to the explorer sub-model; esc from one of its
+		// sub-views lands back on its table, only esc on the table leaves it
+		onTable := m.explorer.state == stateTable
 		var newExplorer tea.Model
 		newExplorer, cmd = m.explorer.Update(msg)
 		m.explorer = newExplorer.(ExplorerModel)
 
 		// If user presses 'q' or 'esc' in explorer main view, go back to dashboard
 		if keyMsg, ok := msg.(tea.KeyMsg); ok {
-			if keyMsg.String() == "esc" && m.explorer.state == stateTable {
+			if keyMsg.String() == "esc" && onTable {
 				m.state = StateHome
 				return m, nil
 			}
@@ -150,7 +198,8 @@ func (m DashboardModel) handleMenuSelect() (tea.Model, tea.Cmd) {
 	switch m.selectedOption {
 	case 0: // Connect Live
 		m.state = StateLiveInput
-		m.textInput.SetValue("http://localhost:6060/debug/pprof/trace?seconds=5")
+		m.textInput.Placeholder = "http://localhost:6060/debug/pprof/trace?seconds=5"
+		m.textInput.SetValue(m.liveURL)
 		return m, nil
 	case 1: // Analyze Local File
 		// For simplicity/demo, just try to load "trace.out" or ask for a file picker later
@@ -169,7 +218,9 @@ func (m DashboardModel) View() string {
 	case StateHome:
 		return m.homeView()
 	case StateLiveInput:
-		return m.inputView("Enter Pprof URL (seconds=5 recommended):")
+		return m.inputView("Enter Pprof URL (seconds=5 recommended, user:pass@host for basic auth):")
+	case StateAuthInput:
+		return m.inputView("Authorization header (Enter to skip):")
 	case StateExploring:
 		return m.explorer.View()
 	case StateError:
@@ -230,6 +281,7 @@ func (m DashboardModel) inputView(prompt string) string {
 type AnalysisResultMsg struct {
 	Summary    *model.Summary
 	Goroutines map[uint64]*model.GoroutineInfo
+	Reanalyze  Reanalyzer
 }
 
 type AnalysisErrorMsg struct {
@@ -253,24 +305,64 @@ func runFileAnalysis(filename string) tea.Cmd {
 
 		parser := traceparser.NewParser()
 		result, err := parser.Parse(f)
-		if err != nil {
+		if err != nil && !errors.Is(err, traceparser.ErrPartialTrace) {
 			return AnalysisErrorMsg{Err: err}
 		}
 
-		// 3. Analyze
-		a := analyzer.NewAnalyzer(result.Goroutines)
-		summary := a.Analyze()
+		// 3. Analyze, keeping the parsed trace for the explorer's toggles
+		reanalyze := newReanalyzer(result)
+		summary, goroutines := reanalyze(AnalysisToggles{})
 
 		return AnalysisResultMsg{
 			Summary:    summary,
-			Goroutines: result.Goroutines,
+			Goroutines: goroutines,
+			Reanalyze:  reanalyze,
+		}
+	}
+}
+
+// newCaptureRequest builds the pprof trace request, moving any URL credentials
+// into a basic auth header and applying the extra headers
+func newCaptureRequest(rawURL string, headers http.Header) (*http.Request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+
+	user := u.User
+	u.User = nil
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if user != nil {
+		if headers.Get("Authorization") != "" {
+			return nil, fmt.Errorf("URL credentials conflict with the Authorization header; use one or the other")
 		}
+		password, _ := user.Password()
+		req.SetBasicAuth(user.Username(), password)
 	}
+	for key, values := range headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+
+	return req, nil
 }
 
 // runLiveCapture fetches pprof trace and then analyzes it
-func runLiveCapture(url string) tea.Cmd {
+func runLiveCapture(rawURL string, headers http.Header) tea.Cmd {
 	return func() tea.Msg {
+		req, err := newCaptureRequest(rawURL, headers)
+		if err != nil {
+			return AnalysisErrorMsg{Err: err}
+		}
+		// Never echo credentials back in error messages
+		url := req.URL.Redacted()
+
 		// Create a temp file
 		// Use unique temp file to avoid race conditions
 		out, err := os.CreateTemp("", "trace_live_*.out")
@@ -278,13 +370,12 @@ func runLiveCapture(url string) tea.Cmd {
 			return AnalysisErrorMsg{Err: err}
 		}
 		tmpFile := out.Name()
-		// Defer cleanup of the temp file (optional, maybe we want to keep it if it fails for inspection?)
-		// For now let's keep it to debug.
-		// defer os.Remove(tmpFile)
+		// Kept for the debug info below and removed when the dashboard exits
+		trackTempTrace(tmpFile)
 
 		// Fetch from URL
 		client := http.Client{Timeout: 15 * time.Second} // Bump timeout slightly
-		resp, err := client.Get(url)
+		resp, err := client.Do(req)
 		if err != nil {
 			out.Close()
 			return AnalysisErrorMsg{Err: fmt.Errorf("failed to fetch pprof: %v", err)}
@@ -0,0 +1,86 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// DOTFormatter renders wait-for graphs in Graphviz DOT format
+type DOTFormatter struct {
+	writer     io.Writer
+	goroutines map[uint64]*model.GoroutineInfo
+}
+
+func init() {
+	RegisterExport("dot", func(w io.Writer, _ Options) Formatter {
+		return NewDOTFormatter(w)
+	})
+}
+
+// NewDOTFormatter creates a DOT formatter
+func NewDOTFormatter(w io.Writer) *DOTFormatter {
+	return &DOTFormatter{writer: w}
+}
+
+// SetGoroutines provides the goroutines whose waits form the graph
+func (f *DOTFormatter) SetGoroutines(goroutines map[uint64]*model.GoroutineInfo) {
+	f.goroutines = goroutines
+}
+
+// FormatSummary outputs the wait graph of the goroutines
+func (f *DOTFormatter) FormatSummary(*model.Summary) error {
+	return f.FormatWaitGraph(analyzer.BuildWaitGraph(f.goroutines))
+}
+
+// FormatGoroutineDetail is not supported: the graph spans every goroutine
+func (f *DOTFormatter) FormatGoroutineDetail(*model.GoroutineInfo) error {
+	return exportOnly("dot")
+}
+
+// FormatInsights is not supported: the graph spans every goroutine
+func (f *DOTFormatter) FormatInsights([]analyzer.NarrativeInsight) error {
+	return exportOnly("dot")
+}
+
+// FormatWaitGraph outputs the wait graph, highlighting edges on a cycle
+func (f *DOTFormatter) FormatWaitGraph(graph *model.WaitGraph) error {
+	onCycle := make(map[[2]uint64]bool)
+	for _, cycle := range graph.Cycles {
+		for i, gid := range cycle {
+			onCycle[[2]uint64{gid, cycle[(i+1)%len(cycle)]}] = true
+		}
+	}
+
+	if _, err := fmt.Fprintln(f.writer, "digraph waitgraph {"); err != nil {
+		return err
+	}
+	fmt.Fprintln(f.writer, `  rankdir=LR;`)
+	fmt.Fprintln(f.writer, `  node [shape=box, style=rounded, fontname="Helvetica"];`)
+
+	nodes := make(map[uint64]bool)
+	for _, e := range graph.Edges {
+		for _, gid := range []uint64{e.From, e.To} {
+			if !nodes[gid] {
+				nodes[gid] = true
+				fmt.Fprintf(f.writer, "  g%d [label=\"#%d\"];\n", gid, gid)
+			}
+		}
+	}
+
+	for _, e := range graph.Edges {
+		attrs := fmt.Sprintf("label=\"%s\\n%s ×%d\"", e.Reason, formatDuration(e.Blocked), e.Count)
+		if e.Current {
+			attrs = fmt.Sprintf("label=\"%s (still waiting)\", style=dashed", e.Reason)
+		}
+		if e.Current && onCycle[[2]uint64{e.From, e.To}] {
+			attrs += ", color=red, penwidth=2"
+		}
+		fmt.Fprintf(f.writer, "  g%d -> g%d [%s];\n", e.From, e.To, attrs)
+	}
+
+	_, err := fmt.Fprintln(f.writer, "}")
+	return err
+}
@@ -3,6 +3,8 @@ package output
 import (
 	"fmt"
 	"io"
+	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -39,6 +41,7 @@ var (
 
 	successStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")).Bold(true)
 	dangerStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF3340")).Bold(true)
+	warningStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#F4D03F")).Bold(true)
 	infoStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#56F4FA")).Bold(true)
 	mutedStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
 
@@ -46,17 +49,94 @@ var (
 	valStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#FAFAFA"))
 )
 
-// Formatter handles human-readable output
-type Formatter struct {
-	writer io.Writer
+// TextFormatter handles human-readable output
+type TextFormatter struct {
+	writer          io.Writer
+	breakdownMinPct float64
+	clock           *model.ClockReference
+	timeUnit        TimeUnit
+	detailEvents    int
 }
 
-// NewFormatter creates an output formatter
-func NewFormatter(w io.Writer) *Formatter {
-	return &Formatter{writer: w}
+func init() {
+	Register("text", func(w io.Writer, opts Options) Formatter {
+		f := NewTextFormatter(w)
+		f.SetTimeUnit(opts.TimeUnit)
+		f.SetBreakdownMinPct(opts.BreakdownMinPct)
+		f.SetClock(opts.Clock)
+		f.SetDetailEvents(opts.TopN)
+		return f
+	})
 }
 
-func (f *Formatter) printBanner() {
+// NewTextFormatter creates a human-readable formatter
+func NewTextFormatter(w io.Writer) *TextFormatter {
+	return &TextFormatter{writer: w, detailEvents: 10}
+}
+
+// SetDetailEvents lists up to n blocking events in goroutine details; 0
+// lists every event
+func (f *TextFormatter) SetDetailEvents(n int) {
+	f.detailEvents = n
+}
+
+// SetBreakdownMinPct collapses blocking reasons below pct percent into a
+// single "other" row of the breakdown
+func (f *TextFormatter) SetBreakdownMinPct(pct float64) {
+	f.breakdownMinPct = pct
+}
+
+// SetClock renders event timestamps as wall-clock times using the reference
+func (f *TextFormatter) SetClock(clock *model.ClockReference) {
+	f.clock = clock
+}
+
+// SetTimeUnit renders every duration in unit instead of auto-scaling each one
+func (f *TextFormatter) SetTimeUnit(unit TimeUnit) {
+	f.timeUnit = unit
+}
+
+// duration renders d in the configured time unit
+func (f *TextFormatter) duration(d time.Duration) string {
+	return f.timeUnit.Format(d)
+}
+
+// formatTimestamp renders a trace timestamp, as wall-clock time when a clock
+// reference is set
+func (f *TextFormatter) formatTimestamp(ts time.Duration) string {
+	if f.clock != nil {
+		return f.clock.WallTime(ts).Format("15:04:05.000000")
+	}
+	return f.duration(ts)
+}
+
+// peakRunnable renders the peak runnable backlog against the Ps serving it
+func peakRunnable(summary *model.Summary) string {
+	procs := "unknown"
+	if summary.GOMAXPROCS > 0 {
+		procs = fmt.Sprintf("%d", summary.GOMAXPROCS)
+	}
+	return fmt.Sprintf("%d goroutines vs GOMAXPROCS=%s", summary.PeakRunnable, procs)
+}
+
+// formatCreatedAt renders when g was created, or that it predates the trace
+func (f *TextFormatter) formatCreatedAt(g *model.GoroutineInfo) string {
+	if g.Preexisting {
+		return mutedStyle.Render("before the trace started")
+	}
+	return f.formatTimestamp(g.CreatedAt)
+}
+
+// formatTerminatedAt renders when g exited and how long it lived, or that it
+// outlived the trace
+func (f *TextFormatter) formatTerminatedAt(g *model.GoroutineInfo) string {
+	if g.TerminatedAt == 0 {
+		return warningStyle.Render("still alive when the trace ended")
+	}
+	return f.formatTimestamp(g.TerminatedAt) + mutedStyle.Render(" (lived "+f.duration(g.Lifetime())+")")
+}
+
+func (f *TextFormatter) printBanner() {
 	banner := `
   ____  _____  ____  _   _  _____ ____  __     _____ _____ 
  / ___|/ _ \ \/ ___|| | | || ____|  _ \ \ \   / /_ _|__  / 
@@ -69,13 +149,25 @@ func (f *Formatter) printBanner() {
 }
 
 // FormatSummary outputs the complete analysis summary
-func (f *Formatter) FormatSummary(summary *model.Summary) error {
+func (f *TextFormatter) FormatSummary(summary *model.Summary) error {
 	f.printBanner()
 	fmt.Fprintln(f.writer, titleStyle.Render(" ANALYSIS COMPLETE "))
 
+	f.FormatTraceHeader(summary)
+	if summary.Incomplete {
+		f.writeIncompleteBanner()
+	}
+
+	f.writeStateSplit(summary)
 	f.writeSummarySection(summary)
 	f.writeBlockingBreakdown(summary)
 	f.writeTopBlocked(summary)
+	f.writeWorstStalls(summary)
+	f.writeMigrationHeavy(summary)
+	f.writeLongestBlocks(summary)
+	f.writeBlockingChains(summary)
+	f.writeCriticalPath(summary)
+	f.writeReasonCycles(summary)
 
 	if summary.HasPerformanceIssues {
 		f.writePerformanceIssues(summary)
@@ -84,21 +176,181 @@ func (f *Formatter) FormatSummary(summary *model.Summary) error {
 	return nil
 }
 
+// FormatTraceHeader outputs what was captured: file, size, duration, Go
+// version, GOMAXPROCS and event counts
+func (f *TextFormatter) FormatTraceHeader(summary *model.Summary) error {
+	t := summary.Trace
+	if t == nil {
+		return nil
+	}
+
+	gomaxprocs := "unknown"
+	if t.GOMAXPROCS > 0 {
+		gomaxprocs = fmt.Sprintf("%d", t.GOMAXPROCS)
+	}
+	events := fmt.Sprintf("%d parsed", t.Events)
+	if t.SkippedEvents > 0 {
+		events += warningStyle.Render(fmt.Sprintf(", %d skipped", t.SkippedEvents))
+	} else {
+		events += ", 0 skipped"
+	}
+
+	content := []string{
+		fmt.Sprintf("%s %s %s", labelStyleGo.Render("Trace:"), valStyle.Render(filepath.Base(t.File)), mutedStyle.Render("("+formatBytes(t.Size)+")")),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Duration:"), valStyle.Render(f.duration(summary.WallTime))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Go version:"), valStyle.Render(t.GoVersion)),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("GOMAXPROCS:"), valStyle.Render(gomaxprocs)),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Events:"), valStyle.Render(events)),
+	}
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(content, "\n")))
+	return nil
+}
+
+// formatBytes renders a size in B, KB, MB or GB
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMG"[exp])
+}
+
+// writeIncompleteBanner warns that the trace was truncated
+func (f *TextFormatter) writeIncompleteBanner() {
+	style := borderStyle.Copy().BorderForeground(lipgloss.Color("#F4D03F"))
+	fmt.Fprintln(f.writer, style.Render("⚠ Trace stream was truncated — results may be incomplete"))
+}
+
+// stateBarWidth is the width of the running/runnable/blocked bar
+const stateBarWidth = 50
+
+// writeStateSplit renders where all goroutine time went as a three-segment bar
+func (f *TextFormatter) writeStateSplit(summary *model.Summary) {
+	running, runnable, blocked := summary.StateSplit()
+	if running+runnable+blocked == 0 {
+		return
+	}
+
+	runW := int(running / 100 * stateBarWidth)
+	queueW := int(runnable / 100 * stateBarWidth)
+	blockW := stateBarWidth - runW - queueW
+
+	bar := successStyle.Render(strings.Repeat("█", runW)) +
+		warningStyle.Render(strings.Repeat("█", queueW)) +
+		dangerStyle.Render(strings.Repeat("█", blockW))
+	legend := fmt.Sprintf("%s %.1f%% running  %s %.1f%% runnable  %s %.1f%% blocked",
+		successStyle.Render("■"), running,
+		warningStyle.Render("■"), runnable,
+		dangerStyle.Render("■"), blocked)
+
+	fmt.Fprintln(f.writer, borderStyle.Render(bar+"\n"+legend))
+}
+
 // writeSummarySection formats the summary metrics
-func (f *Formatter) writeSummarySection(summary *model.Summary) {
+func (f *TextFormatter) writeSummarySection(summary *model.Summary) {
 	fmt.Fprintln(f.writer, headerStyle.Render(" SYSTEM SUMMARY "))
 	content := []string{
 		fmt.Sprintf("%s %s", labelStyleGo.Render("Total Goroutines:"), valStyle.Render(fmt.Sprintf("%d", summary.TotalGoroutines))),
 		fmt.Sprintf("%s %s", labelStyleGo.Render("Peak Goroutines:"), valStyle.Render(fmt.Sprintf("%d", summary.PeakGoroutines))),
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Total Blocked:"), dangerStyle.Render(formatDuration(summary.TotalBlockedTime))),
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Total Runtime:"), successStyle.Render(formatDuration(summary.TotalRuntime))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Alive at End:"), valStyle.Render(fmt.Sprintf("%d", summary.AliveAtEnd))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Peak Runnable:"), valStyle.Render(peakRunnable(summary))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Total Blocked:"), dangerStyle.Render(f.duration(summary.TotalBlockedTime))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Total Runtime:"), successStyle.Render(f.duration(summary.TotalRuntime))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Never Blocked:"), successStyle.Render(f.neverBlocked(summary))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Blocking Gini:"), valStyle.Render(fmt.Sprintf("%.2f", summary.BlockingGini))+mutedStyle.Render(" (0 = spread evenly, 1 = one goroutine)")),
+	}
+
+	if summary.IdealWallTime > 0 {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Ideal vs Actual:"),
+			valStyle.Render(fmt.Sprintf("ideal: %s, actual: %s, %.1f× overhead",
+				f.duration(summary.IdealWallTime),
+				f.duration(summary.WallTime),
+				float64(summary.WallTime)/float64(summary.IdealWallTime)))))
+	}
+
+	if w := summary.Window; w != nil {
+		until := "the end of the trace"
+		if w.Until > 0 {
+			until = f.duration(w.Until) + " into the trace"
+		}
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Window:"),
+			valStyle.Render(fmt.Sprintf("%s to %s (blocking clipped to it)", f.duration(w.Since), until))))
+	}
+
+	if summary.NoiseFloor > 0 {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Noise Floor:"),
+			mutedStyle.Render(fmt.Sprintf("%d events under %s discarded (%s)",
+				summary.NoiseEvents, f.duration(summary.NoiseFloor), f.duration(summary.NoiseTime)))))
+	}
+
+	if gc := summary.GC; gc != nil && gc.Cycles > 0 {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("GC Cycles:"),
+			valStyle.Render(fmt.Sprintf("%d (%.1f/s), avg pause %s, max %s",
+				gc.Cycles, gc.PerSecond, f.duration(gc.AvgPause), f.duration(gc.MaxPause)))))
+	}
+
+	if summary.PrunedGoroutines > 0 {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Detail Kept:"),
+			valStyle.Render(fmt.Sprintf("top %d goroutines; %d more counted in the totals but not listed",
+				summary.TotalGoroutines-summary.PrunedGoroutines, summary.PrunedGoroutines))))
+	}
+
+	if g := summary.Growth; g != nil {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Goroutine Growth:"),
+			warningStyle.Render(fmt.Sprintf("+%d (%d → %d, %.1f/s, R² %.2f)", g.To-g.From, g.From, g.To, g.PerSecond, g.R2))))
+	}
+
+	if o := summary.BlockedOverlap; o != nil {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Blocked Overlap:"),
+			valStyle.Render(fmt.Sprintf("%.0f%% overlapping, %.1f blocked at once on average, peak %d @ %s",
+				o.OverlapShare*100, o.MeanConcurrent, o.Peak, f.duration(o.PeakAt)))))
+	}
+
+	if t := summary.BlockingTiming; t != nil {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Blocking When:"),
+			valStyle.Render(analyzer.DescribeBlockingTiming(t))))
+	}
+
+	if rs := summary.RunnableStarvation; rs != nil {
+		style := valStyle
+		if rs.Anomalous > 0 {
+			style = warningStyle
+		}
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Long Runnable:"),
+			style.Render(fmt.Sprintf("%d with all Ps busy (%s), %d with a P idle (%s)",
+				rs.Saturated, f.duration(rs.SaturatedTime), rs.Anomalous, f.duration(rs.AnomalousTime)))))
+	}
+
+	if c := summary.Churn; c != nil {
+		style := valStyle
+		if c.Thrashing {
+			style = warningStyle
+		}
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Switch Churn:"),
+			style.Render(fmt.Sprintf("%d switches (%.0f/s), avg run slice %s",
+				c.Switches, c.PerSecond, f.duration(c.AvgRunSlice)))))
 	}
 
 	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(content, "\n")))
 }
 
+// neverBlocked describes the goroutines that only did productive work
+func (f *TextFormatter) neverBlocked(summary *model.Summary) string {
+	var pct float64
+	if summary.TotalRuntime > 0 {
+		pct = float64(summary.NeverBlockedRuntime) / float64(summary.TotalRuntime) * 100
+	}
+	return fmt.Sprintf("%d goroutines, accounting for %s (%.1f%%) of total runtime",
+		summary.NeverBlocked, f.duration(summary.NeverBlockedRuntime), pct)
+}
+
 // writeBlockingBreakdown formats the blocking reason percentages
-func (f *Formatter) writeBlockingBreakdown(summary *model.Summary) {
+func (f *TextFormatter) writeBlockingBreakdown(summary *model.Summary) {
 	fmt.Fprintln(f.writer, headerStyle.Render(" BLOCKING BY CATEGORY "))
 	var rows []string
 
@@ -126,6 +378,21 @@ func (f *Formatter) writeBlockingBreakdown(summary *model.Summary) {
 		}
 	}
 
+	// Collapse the long tail of small reasons into one row
+	var other reasonPct
+	otherCount := 0
+	kept := items[:0]
+	for _, item := range items {
+		if f.breakdownMinPct > 0 && item.pct < f.breakdownMinPct {
+			other.pct += item.pct
+			other.duration += item.duration
+			otherCount++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	items = kept
+
 	for _, item := range items {
 		pctStr := fmt.Sprintf("%6.1f%%", item.pct)
 		var style lipgloss.Style
@@ -140,35 +407,223 @@ func (f *Formatter) writeBlockingBreakdown(summary *model.Summary) {
 		rows = append(rows, fmt.Sprintf("%s %s %s",
 			labelStyleGo.Render(item.reason.String()+":"),
 			style.Render(pctStr),
-			mutedStyle.Render("("+formatDuration(item.duration)+")")))
+			mutedStyle.Render("("+f.duration(item.duration)+")")))
+	}
+
+	if otherCount > 0 {
+		rows = append(rows, fmt.Sprintf("%s %s %s",
+			labelStyleGo.Render("other:"),
+			mutedStyle.Render(fmt.Sprintf("%6.1f%%", other.pct)),
+			mutedStyle.Render(fmt.Sprintf("(%s, %d reasons)", f.duration(other.duration), otherCount))))
+	}
+
+	if n := summary.BlockingEventCount[model.BlockSelect]; n > 0 {
+		var parts []string
+		for _, c := range []model.SelectCase{model.SelectRecv, model.SelectSend, model.SelectTimeout, model.SelectUnknown} {
+			if count := summary.SelectOutcomes[c]; count > 0 {
+				parts = append(parts, fmt.Sprintf("%s %.0f%%", c, float64(count)/float64(n)*100))
+			}
+		}
+		rows = append(rows, mutedStyle.Render("select ended on: "+strings.Join(parts, ", ")))
 	}
 
 	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
 }
 
 // writeTopBlocked formats the top blocked goroutines
-func (f *Formatter) writeTopBlocked(summary *model.Summary) {
+func (f *TextFormatter) writeTopBlocked(summary *model.Summary) {
 	if len(summary.TopBlocked) == 0 {
 		return
 	}
 
 	fmt.Fprintln(f.writer, headerStyle.Render(" TOP BOTTLENECKS "))
 	var rows []string
-	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-12s %-12s %s", "GOROUTINE", "DURATION", "CAUSE")))
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-20s %-12s %s", "GOROUTINE", "DURATION", "CAUSE")))
 
 	for _, g := range summary.TopBlocked {
 		primaryReason := getPrimaryBlockingReason(g)
-		rows = append(rows, fmt.Sprintf("%-12s %-12s %s",
-			infoStyle.Render(fmt.Sprintf("#%d", g.ID)),
-			valStyle.Render(formatDuration(g.TotalBlocked)),
+		rows = append(rows, fmt.Sprintf("%s %-12s %s",
+			infoStyle.Render(nameColumn(g.DisplayName())),
+			valStyle.Render(f.duration(g.TotalBlocked)),
 			mutedStyle.Render(primaryReason.String())))
 	}
 
 	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
 }
 
+// nameWidth is the width of the GOROUTINE column in the summary tables
+const nameWidth = 20
+
+// nameColumn pads a goroutine name to nameWidth, or cuts it short with an
+// ellipsis, before it is styled so the columns after it line up
+func nameColumn(name string) string {
+	if runes := []rune(name); len(runes) > nameWidth {
+		return string(runes[:nameWidth-1]) + "…"
+	}
+	return fmt.Sprintf("%-*s", nameWidth, name)
+}
+
+// writeWorstStalls formats the goroutines with the longest single block
+func (f *TextFormatter) writeWorstStalls(summary *model.Summary) {
+	if len(summary.WorstStalls) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" WORST SINGLE STALLS "))
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-20s %-12s %-18s %s", "GOROUTINE", "STALL", "REASON", "AT")))
+
+	for _, g := range summary.WorstStalls {
+		ev := g.LongestBlock
+		rows = append(rows, fmt.Sprintf("%s %-12s %-18s %s",
+			infoStyle.Render(nameColumn(g.DisplayName())),
+			dangerStyle.Render(f.duration(ev.Duration)),
+			valStyle.Render(ev.Reason.String()),
+			mutedStyle.Render("@ "+f.duration(ev.StartTime-summary.TraceStart))))
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
+// writeMigrationHeavy formats the goroutines that keep changing OS thread
+func (f *TextFormatter) writeMigrationHeavy(summary *model.Summary) {
+	if len(summary.MigrationHeavy) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" THREAD MIGRATION "))
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-20s %-12s %-10s %s", "GOROUTINE", "MIGRATIONS", "RUNS", "RATE")))
+
+	for _, g := range summary.MigrationHeavy {
+		rows = append(rows, fmt.Sprintf("%s %-12s %-10s %s",
+			infoStyle.Render(nameColumn(g.DisplayName())),
+			warningStyle.Render(fmt.Sprintf("%d", g.Migrations)),
+			valStyle.Render(fmt.Sprintf("%d", g.Schedules)),
+			mutedStyle.Render(fmt.Sprintf("%.0f%%", float64(g.Migrations)/float64(g.Schedules)*100))))
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
+// writeBlockingChains formats causal blocking chains, root cause last
+func (f *TextFormatter) writeBlockingChains(summary *model.Summary) {
+	if len(summary.BlockingChains) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" BLOCKING CHAINS "))
+	var rows []string
+	for i, c := range summary.BlockingChains {
+		links := make([]string, len(c.Goroutines))
+		for j, gid := range c.Goroutines {
+			links[j] = fmt.Sprintf("#%d", gid)
+			if j < len(c.Reasons) {
+				links[j] += mutedStyle.Render(" (" + c.Reasons[j].String() + ")")
+			}
+		}
+		if i > 0 {
+			rows = append(rows, "")
+		}
+		rows = append(rows,
+			strings.Join(links, " → "),
+			fmt.Sprintf("  %s total over %d stalls (max %s), root cause %s",
+				dangerStyle.Render(f.duration(c.TotalLatency)),
+				c.Occurrences,
+				f.duration(c.MaxLatency),
+				infoStyle.Render(fmt.Sprintf("#%d", c.RootCause()))))
+	}
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
+// writeCriticalPath formats the blocking that gated the root goroutine,
+// split by reason and by the goroutine that held it up
+func (f *TextFormatter) writeCriticalPath(summary *model.Summary) {
+	cp := summary.CriticalPath
+	if cp == nil {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" CRITICAL PATH "))
+	var pct float64
+	if summary.TotalBlockedTime > 0 {
+		pct = float64(cp.Blocked) / float64(summary.TotalBlockedTime) * 100
+	}
+	rows := []string{
+		fmt.Sprintf("Root #%d waited %s, %.0f%% of all blocked time; %s was off the path",
+			cp.Root, dangerStyle.Render(f.duration(cp.Blocked)), pct, mutedStyle.Render(f.duration(cp.OffPath))),
+		"",
+		subHeaderStyle.Render(fmt.Sprintf("%-20s %s", "REASON", "ON PATH")),
+	}
+	reasons := make([]model.BlockingReason, 0, len(cp.ByReason))
+	for r := range cp.ByReason {
+		reasons = append(reasons, r)
+	}
+	sort.Slice(reasons, func(i, j int) bool {
+		if cp.ByReason[reasons[i]] != cp.ByReason[reasons[j]] {
+			return cp.ByReason[reasons[i]] > cp.ByReason[reasons[j]]
+		}
+		return reasons[i] < reasons[j]
+	})
+	for _, r := range reasons {
+		rows = append(rows, fmt.Sprintf("%-20s %s", r.String(), valStyle.Render(f.duration(cp.ByReason[r]))))
+	}
+	rows = append(rows, "", subHeaderStyle.Render(fmt.Sprintf("%-20s %s", "GOROUTINE", "ON PATH")))
+	for _, share := range cp.Goroutines {
+		rows = append(rows, fmt.Sprintf("%-20s %s", infoStyle.Render(fmt.Sprintf("#%d", share.ID)), valStyle.Render(f.duration(share.Duration))))
+	}
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
+// writeReasonCycles formats goroutines looping between two blocking reasons
+func (f *TextFormatter) writeReasonCycles(summary *model.Summary) {
+	if len(summary.ReasonCycles) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" REASON CYCLES "))
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-20s %-36s %s", "GOROUTINE", "CYCLE", "ALTERNATIONS")))
+	for _, c := range summary.ReasonCycles {
+		name := fmt.Sprintf("#%d", c.GoroutineID)
+		if c.Label != "" {
+			name += " " + c.Label
+		}
+		rows = append(rows, fmt.Sprintf("%s %-36s %d (%.0f%%)",
+			infoStyle.Render(nameColumn(name)),
+			valStyle.Render(c.A.String()+" ⇄ "+c.B.String()),
+			c.Alternations,
+			c.Share*100))
+	}
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
+// writeLongestBlocks formats the longest individual blocking events
+func (f *TextFormatter) writeLongestBlocks(summary *model.Summary) {
+	if len(summary.LongestBlocks) == 0 {
+		return
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" LONGEST BLOCKS "))
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-12s %-18s %-12s %s", "DURATION", "REASON", "GOROUTINE", "AT")))
+
+	for _, b := range summary.LongestBlocks {
+		rows = append(rows, fmt.Sprintf("%-12s %-18s %-12s %s",
+			dangerStyle.Render(f.duration(b.Event.Duration)),
+			valStyle.Render(b.Event.Reason.String()),
+			infoStyle.Render(fmt.Sprintf("#%d", b.GoroutineID)),
+			mutedStyle.Render("@ "+f.duration(b.Event.StartTime-summary.TraceStart))))
+		if st := b.Event.Stack; st != nil && st.TopFrame != "" {
+			rows = append(rows, mutedStyle.Render("    "+st.TopFrame))
+		}
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+}
+
 // writePerformanceIssues formats detected issues
-func (f *Formatter) writePerformanceIssues(summary *model.Summary) {
+func (f *TextFormatter) writePerformanceIssues(summary *model.Summary) {
 	fmt.Fprintln(f.writer, headerStyle.Foreground(lipgloss.Color("#EF3340")).Render(" PERFORMANCE ALERTS "))
 	var sb strings.Builder
 	for i, issue := range summary.Issues {
@@ -180,48 +635,113 @@ func (f *Formatter) writePerformanceIssues(summary *model.Summary) {
 }
 
 // FormatGoroutineDetail outputs detailed info for a specific goroutine
-func (f *Formatter) FormatGoroutineDetail(g *model.GoroutineInfo) error {
-	fmt.Fprintln(f.writer, titleStyle.Render(fmt.Sprintf(" GOROUTINE #%d ANALYSIS ", g.ID)))
+func (f *TextFormatter) FormatGoroutineDetail(g *model.GoroutineInfo) error {
+	fmt.Fprintln(f.writer, titleStyle.Render(fmt.Sprintf(" GOROUTINE %s ANALYSIS ", g.DisplayName())))
 
-	content := []string{
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Created at:"), formatDuration(g.CreatedAt)),
+	var content []string
+	if g.Name != "" {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Entry function:"), infoStyle.Render(g.Name)))
+	}
+	content = append(content,
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Created at:"), f.formatCreatedAt(g)),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Terminated at:"), f.formatTerminatedAt(g)),
 		fmt.Sprintf("%s %s", labelStyleGo.Render("Current state:"), infoStyle.Render(g.CurrentState.String())),
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Total runtime:"), successStyle.Render(formatDuration(g.TotalRuntime))),
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Total runnable:"), valStyle.Render(formatDuration(g.TotalRunnable))),
-		fmt.Sprintf("%s %s", labelStyleGo.Render("Total blocked:"), dangerStyle.Render(formatDuration(g.TotalBlocked))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Total runtime:"), successStyle.Render(f.duration(g.TotalRuntime))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Total runnable:"), valStyle.Render(f.duration(g.TotalRunnable))),
+		fmt.Sprintf("%s %s", labelStyleGo.Render("Total blocked:"), dangerStyle.Render(f.duration(g.TotalBlocked))),
+	)
+	if g.CreationHash != "" {
+		content = append(content, fmt.Sprintf("%s %s", labelStyleGo.Render("Creation hash:"), mutedStyle.Render(g.CreationHash)))
 	}
 
 	fmt.Fprintln(f.writer, headerStyle.Render(" METRICS "))
 	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(content, "\n")))
 
+	if len(g.CreationStack) > 0 {
+		fmt.Fprintln(f.writer, headerStyle.Render(" CREATED BY "))
+		fmt.Fprintln(f.writer, borderStyle.Render(mutedStyle.Render(strings.Join(g.CreationStack, "\n"))))
+	}
+
+	// A single long stall is what users notice, so it gets its own box
+	if ev := g.LongestBlock; ev.Duration > 0 {
+		fmt.Fprintln(f.writer, headerStyle.Render(" WORST STALL "))
+		fmt.Fprintln(f.writer, borderStyle.Render(fmt.Sprintf("%s %s %s",
+			warningStyle.Render(f.duration(ev.Duration)),
+			infoStyle.Render(ev.Reason.String()),
+			mutedStyle.Render("@ "+f.formatTimestamp(ev.StartTime)))))
+	}
+
 	var rows []string
 	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-12s %-12s %s", "INDEX", "DURATION", "TIMESTAMP")))
 
-	displayCount := 10
-	if len(g.BlockingEvents) < displayCount {
+	displayCount := f.detailEvents
+	if displayCount <= 0 || len(g.BlockingEvents) < displayCount {
 		displayCount = len(g.BlockingEvents)
 	}
 
 	for i := 0; i < displayCount; i++ {
 		ev := g.BlockingEvents[i]
-		rows = append(rows, fmt.Sprintf("%-12d %-12s %s %s",
+		row := fmt.Sprintf("%-12d %-12s %s %s",
 			i+1,
 			infoStyle.Render(ev.Reason.String()),
-			valStyle.Render(formatDuration(ev.Duration)),
-			mutedStyle.Render("@ "+formatDuration(ev.StartTime))))
+			valStyle.Render(f.duration(ev.Duration)),
+			mutedStyle.Render("@ "+f.formatTimestamp(ev.StartTime)))
+		if st := ev.Stack; st != nil && st.TopFrame != "" {
+			row += " " + mutedStyle.Render("in") + " " + st.TopFrame
+		}
+		rows = append(rows, row)
 	}
 
 	if len(g.BlockingEvents) > displayCount {
 		rows = append(rows, mutedStyle.Render(fmt.Sprintf("\n... and %d more events", len(g.BlockingEvents)-displayCount)))
 	}
+	if g.DroppedEvents > 0 {
+		rows = append(rows, mutedStyle.Render(fmt.Sprintf("(%d shorter events not retained)", g.DroppedEvents)))
+	}
 
 	fmt.Fprintln(f.writer, headerStyle.Render(" EVENTS TIMELINE "))
 	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
 	return nil
 }
 
+// FormatGoroutineComparison renders the key metrics of several goroutines in
+// adjacent columns, so workers that should behave alike can be compared
+func (f *TextFormatter) FormatGoroutineComparison(goroutines []*model.GoroutineInfo) error {
+	fmt.Fprintln(f.writer, titleStyle.Render(fmt.Sprintf(" COMPARING %d GOROUTINES ", len(goroutines))))
+
+	columns := make([]string, 0, len(goroutines))
+	for _, g := range goroutines {
+		content := []string{
+			subHeaderStyle.Render(g.DisplayName()),
+			labelStyleGo.Render("Created at:") + " " + f.formatCreatedAt(g),
+			labelStyleGo.Render("Terminated at:") + " " + f.formatTerminatedAt(g),
+			labelStyleGo.Render("Current state:") + " " + infoStyle.Render(g.CurrentState.String()),
+			labelStyleGo.Render("Total runtime:") + " " + successStyle.Render(f.duration(g.TotalRuntime)),
+			labelStyleGo.Render("Total runnable:") + " " + valStyle.Render(f.duration(g.TotalRunnable)),
+			labelStyleGo.Render("Total blocked:") + " " + dangerStyle.Render(f.duration(g.TotalBlocked)),
+			labelStyleGo.Render("Primary reason:") + " " + infoStyle.Render(getPrimaryBlockingReason(g).String()),
+			labelStyleGo.Render("Blocking events:") + " " + valStyle.Render(fmt.Sprintf("%d", len(g.BlockingEvents)+g.DroppedEvents)),
+		}
+		if ev := g.LongestBlock; ev.Duration > 0 {
+			content = append(content,
+				labelStyleGo.Render("Worst stall:")+" "+warningStyle.Render(f.duration(ev.Duration))+" "+infoStyle.Render(ev.Reason.String()))
+		}
+		columns = append(columns, borderStyle.Render(strings.Join(content, "\n")))
+	}
+
+	fmt.Fprintln(f.writer, lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	return nil
+}
+
+// FormatVerdict outputs the one-sentence bottom line of the analysis
+func (f *TextFormatter) FormatVerdict(verdict string) error {
+	style := borderStyle.Copy().BorderForeground(lipgloss.Color("#56F4FA")).MarginTop(1).Width(80)
+	fmt.Fprintln(f.writer, style.Render(infoStyle.Render(verdict)))
+	return nil
+}
+
 // FormatInsights outputs narrative insights generated by the analyzer
-func (f *Formatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
+func (f *TextFormatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
 	fmt.Fprintln(f.writer, titleStyle.Render(" SYSTEM INSIGHTS & OBSERVATIONS "))
 
 	if len(insights) == 0 {
@@ -250,6 +770,9 @@ func (f *Formatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
 			valStyle.Render(insight.Observation),
 			infoStyle.Render("💡 Suggestion:"),
 			mutedStyle.Render(insight.Suggestion))
+		if insight.DocLink != "" {
+			content += fmt.Sprintf("\n%s %s", infoStyle.Render("📖 Learn more:"), mutedStyle.Render(insight.DocLink))
+		}
 
 		box := borderStyle.Copy().BorderForeground(lipgloss.Color(colorStr)).Render(content)
 
@@ -260,6 +783,32 @@ func (f *Formatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
 	return nil
 }
 
+// FormatRecommendations outputs the ranked list of suggested fixes
+func (f *TextFormatter) FormatRecommendations(recs []analyzer.Recommendation) error {
+	if len(recs) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(f.writer, headerStyle.Render(" BIGGEST WINS "))
+	efforts := map[int]string{1: "low effort", 2: "medium effort", 3: "high effort"}
+
+	var rows []string
+	for i, rec := range recs {
+		row := fmt.Sprintf("%d) %s %s",
+			i+1,
+			valStyle.Render(rec.Where()),
+			successStyle.Render(fmt.Sprintf("(~%.0f%% est. gain)", rec.EstimatedGain)))
+		detail := fmt.Sprintf("   %s, %s", rec.Reason, efforts[rec.Effort])
+		if rec.ExampleID != 0 {
+			detail += fmt.Sprintf(", e.g. goroutine #%d", rec.ExampleID)
+		}
+		rows = append(rows, row, mutedStyle.Render(detail))
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+	return nil
+}
+
 // formatDuration converts duration to human-readable string
 func formatDuration(d time.Duration) string {
 	if d == 0 {
@@ -298,3 +847,36 @@ func getPrimaryBlockingReason(g *model.GoroutineInfo) model.BlockingReason {
 func GetTitleStyle() lipgloss.Style {
 	return titleStyle
 }
+
+// DoctorCheck is a single item of the live-capture setup checklist
+type DoctorCheck struct {
+	Name   string
+	OK     bool
+	Detail string
+	Hint   string
+}
+
+// FormatDoctorReport outputs the live-capture setup checklist
+func (f *TextFormatter) FormatDoctorReport(target string, checks []DoctorCheck) error {
+	fmt.Fprintln(f.writer, titleStyle.Render(" GOSCHEDVIZ DOCTOR "))
+	fmt.Fprintln(f.writer, mutedStyle.Render("Target: "+target))
+
+	var rows []string
+	for _, c := range checks {
+		if c.OK {
+			row := successStyle.Render("✔ ") + valStyle.Render(c.Name)
+			if c.Detail != "" {
+				row += " " + mutedStyle.Render("("+c.Detail+")")
+			}
+			rows = append(rows, row)
+			continue
+		}
+		rows = append(rows, dangerStyle.Render("✖ ")+valStyle.Render(c.Name))
+		if c.Hint != "" {
+			rows = append(rows, "  "+infoStyle.Render("💡 ")+mutedStyle.Render(c.Hint))
+		}
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+	return nil
+}
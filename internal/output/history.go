@@ -0,0 +1,102 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// HistoryEntry is one saved analysis run
+type HistoryEntry struct {
+	Name    string      `json:"name"`
+	Trace   string      `json:"trace"`
+	SavedAt time.Time   `json:"saved_at"`
+	Summary *JSONOutput `json:"summary"`
+}
+
+// DefaultHistoryPath returns the per-user history file location
+func DefaultHistoryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goschedviz", "history.json"), nil
+}
+
+// LoadHistory reads saved runs from path; a missing file is an empty history
+func LoadHistory(path string) ([]HistoryEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []HistoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("reading history %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// SaveRun appends the summary to the history file at path under name
+func SaveRun(path, name, trace string, summary *model.Summary) error {
+	entries, err := LoadHistory(path)
+	if err != nil {
+		return err
+	}
+	entries = append(entries, HistoryEntry{
+		Name:    name,
+		Trace:   trace,
+		SavedAt: time.Now(),
+		Summary: (&JSONFormatter{}).convertToJSON(summary),
+	})
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// FormatHistory outputs saved runs oldest first with their key metrics
+func (f *TextFormatter) FormatHistory(entries []HistoryEntry) error {
+	fmt.Fprintln(f.writer, titleStyle.Render(" SAVED RUNS "))
+	if len(entries) == 0 {
+		fmt.Fprintln(f.writer, mutedStyle.Render("\nNo runs saved yet. Use 'goschedviz analyze --save-run <name> <trace>'."))
+		return nil
+	}
+
+	var rows []string
+	rows = append(rows, subHeaderStyle.Render(fmt.Sprintf("%-24s %-17s %-11s %-16s %-12s %s", "NAME", "SAVED", "GOROUTINES", "BLOCKED", "WALL", "ISSUES")))
+	for _, e := range entries {
+		s := e.Summary
+		if s == nil {
+			continue
+		}
+		issues := successStyle.Render("0")
+		if len(s.Issues) > 0 {
+			issues = dangerStyle.Render(fmt.Sprintf("%d", len(s.Issues)))
+		}
+		rows = append(rows, fmt.Sprintf("%-24s %-17s %-11d %-16s %-12s %s",
+			infoStyle.Render(e.Name),
+			e.SavedAt.Format("2006-01-02 15:04"),
+			s.TotalGoroutines,
+			s.TotalBlockedTime,
+			s.WallTime,
+			issues))
+	}
+
+	fmt.Fprintln(f.writer, borderStyle.Render(strings.Join(rows, "\n")))
+	return nil
+}
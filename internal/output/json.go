@@ -1,23 +1,238 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
+	"slices"
+	"sort"
 	"time"
 
+	"github.com/goschedviz/goschedviz/internal/analyzer"
 	"github.com/goschedviz/goschedviz/internal/model"
 )
 
 // JSONOutput represents the JSON structure
 type JSONOutput struct {
-	TotalGoroutines   int                            `json:"total_goroutines"`
-	PeakGoroutines    int                            `json:"peak_goroutines"`
-	TotalBlockedTime  string                         `json:"total_blocked_time"`
-	TotalRuntime      string                         `json:"total_runtime"`
-	BlockingBreakdown map[string]BlockingReasonStats `json:"blocking_breakdown"`
-	TopBlocked        []GoroutineJSON                `json:"top_blocked_goroutines"`
-	PerformanceIssues bool                           `json:"has_performance_issues"`
-	Issues            []string                       `json:"issues,omitempty"`
+	Trace             *TraceJSON          `json:"trace,omitempty"`
+	TotalGoroutines   int                 `json:"total_goroutines"`
+	PeakGoroutines    int                 `json:"peak_goroutines"`
+	AliveAtEnd        int                 `json:"alive_at_end"`
+	GOMAXPROCS        int                 `json:"gomaxprocs"`
+	PeakRunnable      int                 `json:"peak_runnable"`
+	TotalBlockedTime  string              `json:"total_blocked_time"`
+	TotalRuntime      string              `json:"total_runtime"`
+	TotalRunnable     string              `json:"total_runnable"`
+	FinalizerRuntime  string              `json:"finalizer_runtime,omitempty"`
+	StateSplit        StateSplitJSON      `json:"state_split"`
+	NeverBlocked      NeverBlockedJSON    `json:"never_blocked"`
+	Noise             *NoiseJSON          `json:"noise,omitempty"`
+	Window            *WindowJSON         `json:"window,omitempty"`
+	WallTime          string              `json:"wall_time"`
+	IdealWallTime     string              `json:"ideal_wall_time,omitempty"`
+	OverheadFactor    float64             `json:"overhead_factor,omitempty"`
+	BlockingBreakdown BreakdownJSON       `json:"blocking_breakdown"`
+	BlockingGini      float64             `json:"blocking_gini"`
+	TopBlocked        []GoroutineJSON     `json:"top_blocked_goroutines"`
+	WorstStalls       []BlockJSON         `json:"worst_stalls,omitempty"`
+	TotalMigrations   int                 `json:"total_migrations"`
+	MigrationHeavy    []MigrationJSON     `json:"migration_heavy,omitempty"`
+	LongestBlocks     []BlockJSON         `json:"longest_blocks,omitempty"`
+	BlockingChains    []ChainJSON         `json:"blocking_chains,omitempty"`
+	CriticalPath      *CriticalPathJSON   `json:"critical_path,omitempty"`
+	ReasonCycles      []ReasonCycleJSON   `json:"reason_cycles,omitempty"`
+	SelectOutcomes    map[string]int      `json:"select_outcomes,omitempty"`
+	ConcurrencyCap    *ConcurrencyCapJSON `json:"concurrency_cap,omitempty"`
+	Cliffs            []CliffJSON         `json:"goroutine_cliffs,omitempty"`
+	GC                *GCJSON             `json:"gc,omitempty"`
+	Churn             *ChurnJSON          `json:"scheduler_churn,omitempty"`
+	Growth            *GrowthJSON         `json:"goroutine_growth,omitempty"`
+	ChannelImbalances []ImbalanceJSON     `json:"channel_imbalances,omitempty"`
+	Starvation        *StarvationJSON     `json:"runnable_starvation,omitempty"`
+	BlockingTiming    *BlockingTimingJSON `json:"blocking_timing,omitempty"`
+	BlockedOverlap    *BlockedOverlapJSON `json:"blocked_overlap,omitempty"`
+	Verdict           string              `json:"verdict"`
+	PerformanceIssues bool                `json:"has_performance_issues"`
+	Issues            []string            `json:"issues,omitempty"`
+	Incomplete        bool                `json:"incomplete,omitempty"`
+	PrunedGoroutines  int                 `json:"pruned_goroutines,omitempty"`
+}
+
+// TraceJSON describes the capture the analysis was computed from
+type TraceJSON struct {
+	File          string `json:"file"`
+	SizeBytes     int64  `json:"size_bytes"`
+	Duration      string `json:"duration"`
+	GoVersion     string `json:"go_version"`
+	GOMAXPROCS    int    `json:"gomaxprocs,omitempty"`
+	Events        int64  `json:"events"`
+	SkippedEvents int    `json:"skipped_events"`
+}
+
+// StateSplitJSON is the percentage of goroutine time spent in each state
+type StateSplitJSON struct {
+	Running  float64 `json:"running_pct"`
+	Runnable float64 `json:"runnable_pct"`
+	Blocked  float64 `json:"blocked_pct"`
+}
+
+// NeverBlockedJSON describes the goroutines that ran without ever blocking
+type NeverBlockedJSON struct {
+	Goroutines int     `json:"goroutines"`
+	Runtime    string  `json:"runtime"`
+	RuntimePct float64 `json:"runtime_pct"`
+}
+
+// NoiseJSON reports the blocking events discarded below the noise floor
+type NoiseJSON struct {
+	Floor  string `json:"floor"`
+	Events int    `json:"discarded_events"`
+	Time   string `json:"discarded_time"`
+}
+
+// WindowJSON is the part of the trace blocking was aggregated over; Until is
+// omitted when the window runs to the end of the trace
+type WindowJSON struct {
+	Since string `json:"since"`
+	Until string `json:"until,omitempty"`
+}
+
+// MigrationJSON is a goroutine that often resumed on a different OS thread
+type MigrationJSON struct {
+	GoroutineID uint64 `json:"goroutine_id"`
+	Label       string `json:"label,omitempty"`
+	Migrations  int    `json:"migrations"`
+	Schedules   int    `json:"schedules"`
+}
+
+// CliffJSON is a sudden drop in the live goroutine count
+type CliffJSON struct {
+	Offset        string `json:"offset"`
+	Duration      string `json:"duration"`
+	From          int    `json:"from"`
+	To            int    `json:"to"`
+	UnblockReason string `json:"unblock_reason,omitempty"`
+	UnblockCount  int    `json:"unblock_count,omitempty"`
+}
+
+// ConcurrencyCapJSON is the recommended worker pool size
+type ConcurrencyCapJSON struct {
+	PeakGoroutines int     `json:"peak_goroutines"`
+	Parallelism    float64 `json:"effective_parallelism"`
+	Recommended    int     `json:"recommended_cap"`
+}
+
+// GCJSON is how often the garbage collector ran and how long it paused
+type GCJSON struct {
+	Cycles          int     `json:"cycles"`
+	CyclesPerSecond float64 `json:"cycles_per_second"`
+	Pauses          int     `json:"pauses"`
+	AvgPause        string  `json:"avg_pause"`
+	MaxPause        string  `json:"max_pause"`
+}
+
+// BlockingTimingJSON places blocked time along the trace
+type BlockingTimingJSON struct {
+	Centroid    float64    `json:"centroid"`
+	ThirdsPct   [3]float64 `json:"thirds_pct"`
+	Phase       string     `json:"phase"`
+	Description string     `json:"description"`
+}
+
+// BlockedOverlapJSON measures simultaneous blocking; windows are the average
+// number of goroutines blocked in each timeline window
+type BlockedOverlapJSON struct {
+	OverlapTime    string    `json:"overlap_goroutine_time"`
+	OverlapShare   float64   `json:"overlap_share"`
+	MeanConcurrent float64   `json:"mean_concurrent"`
+	Peak           int       `json:"peak"`
+	PeakOffset     string    `json:"peak_offset"`
+	Windows        []float64 `json:"windows,omitempty"`
+}
+
+// ImbalanceJSON is a channel site where many blocked goroutines were served
+// by few peers
+type ImbalanceJSON struct {
+	Site        string `json:"site"`
+	Reason      string `json:"reason"`
+	Blocked     int    `json:"blocked_goroutines"`
+	Peers       int    `json:"peer_goroutines"`
+	BlockedTime string `json:"blocked_time"`
+}
+
+// GrowthJSON is the linear fit of a steadily growing goroutine count
+type GrowthJSON struct {
+	From      int     `json:"from"`
+	To        int     `json:"to"`
+	Growth    int     `json:"growth"`
+	PerSecond float64 `json:"per_second"`
+	R2        float64 `json:"r2"`
+}
+
+// ChurnJSON is the runnable→running switch rate against the average run slice
+type ChurnJSON struct {
+	Switches      int     `json:"switches"`
+	PerSecond     float64 `json:"switches_per_second"`
+	PerProcSecond float64 `json:"switches_per_proc_second,omitempty"`
+	AvgRunSlice   string  `json:"avg_run_slice"`
+	Thrashing     bool    `json:"thrashing"`
+}
+
+// StarvationJSON splits long runnable waits into those during which every P
+// was busy and those that overlapped an idle P
+type StarvationJSON struct {
+	Saturated     int               `json:"saturated"`
+	SaturatedTime string            `json:"saturated_time"`
+	Anomalous     int               `json:"anomalous"`
+	AnomalousTime string            `json:"anomalous_time"`
+	WorstAnomaly  *RunnableWaitJSON `json:"worst_anomaly,omitempty"`
+}
+
+// RunnableWaitJSON is one goroutine's wait in the run queue
+type RunnableWaitJSON struct {
+	GoroutineID uint64  `json:"goroutine_id"`
+	Offset      string  `json:"offset"`
+	Duration    string  `json:"duration"`
+	IdleShare   float64 `json:"idle_share"`
+}
+
+// BreakdownJSON maps reason names to their stats. It marshals with the
+// largest share first (ties by name), so reports diff cleanly across runs.
+type BreakdownJSON map[string]BlockingReasonStats
+
+// MarshalJSON writes the entries ordered by descending percentage
+func (b BreakdownJSON) MarshalJSON() ([]byte, error) {
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if b[keys[i]].Percentage != b[keys[j]].Percentage {
+			return b[keys[i]].Percentage > b[keys[j]].Percentage
+		}
+		return keys[i] < keys[j]
+	})
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(b[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
 }
 
 // BlockingReasonStats contains stats for a blocking reason
@@ -26,20 +241,107 @@ type BlockingReasonStats struct {
 	Percentage float64 `json:"percentage"`
 }
 
+// BlockJSON represents a single blocking event in JSON
+type BlockJSON struct {
+	GoroutineID uint64     `json:"goroutine_id"`
+	Reason      string     `json:"reason"`
+	Duration    string     `json:"duration"`
+	Offset      string     `json:"offset"`
+	Stack       *StackJSON `json:"stack,omitempty"`
+}
+
+// StackJSON is where a goroutine blocked, captured with --stacks
+type StackJSON struct {
+	TopFrame string   `json:"top_frame"`
+	Frames   []string `json:"frames"`
+}
+
+// stackJSON converts a captured stack, nil when none was captured
+func stackJSON(st *model.EventStack) *StackJSON {
+	if st == nil {
+		return nil
+	}
+	return &StackJSON{TopFrame: st.TopFrame, Frames: st.Frames}
+}
+
+// CriticalPathJSON is the blocking that gated the root goroutine
+type CriticalPathJSON struct {
+	Root       uint64            `json:"root"`
+	Blocked    string            `json:"blocked"`
+	OffPath    string            `json:"off_path"`
+	ByReason   map[string]string `json:"by_reason"`
+	Goroutines []GoroutineShare  `json:"goroutines"`
+}
+
+// GoroutineShare is one goroutine's part of the critical path
+type GoroutineShare struct {
+	ID       uint64 `json:"id"`
+	Duration string `json:"duration"`
+}
+
+// ChainJSON represents a causal blocking chain in JSON, root cause last
+type ChainJSON struct {
+	Goroutines   []uint64 `json:"goroutines"`
+	Reasons      []string `json:"reasons"`
+	RootCause    uint64   `json:"root_cause"`
+	Occurrences  int      `json:"occurrences"`
+	TotalLatency string   `json:"total_latency"`
+	MaxLatency   string   `json:"max_latency"`
+}
+
+// ReasonCycleJSON represents a two-reason blocking loop in JSON
+type ReasonCycleJSON struct {
+	GoroutineID  uint64   `json:"goroutine_id"`
+	Label        string   `json:"label,omitempty"`
+	Reasons      []string `json:"reasons"`
+	Alternations int      `json:"alternations"`
+	Share        float64  `json:"share"`
+}
+
 // GoroutineJSON represents a goroutine in JSON
 type GoroutineJSON struct {
 	ID               uint64            `json:"id"`
+	Label            string            `json:"label,omitempty"`
+	Name             string            `json:"name,omitempty"`
+	CreationStack    []string          `json:"creation_stack,omitempty"`
+	CreationHash     string            `json:"creation_hash,omitempty"`
+	Preexisting      bool              `json:"preexisting,omitempty"`
+	Lifetime         string            `json:"lifetime,omitempty"`
 	TotalBlocked     string            `json:"total_blocked"`
 	TotalRuntime     string            `json:"total_runtime"`
 	TotalRunnable    string            `json:"total_runnable"`
 	PrimaryReason    string            `json:"primary_blocking_reason"`
 	BlockingEvents   int               `json:"blocking_events_count"`
+	LongestBlock     string            `json:"longest_block"`
+	Migrations       int               `json:"migrations,omitempty"`
 	BlockingByReason map[string]string `json:"blocking_by_reason,omitempty"`
+	Events           []EventJSON       `json:"blocking_events,omitempty"`
+}
+
+// EventJSON is one blocking event on the trace clock, so events of different
+// goroutines line up on a common timeline
+type EventJSON struct {
+	StartNs    int64      `json:"start_ns"`
+	DurationNs int64      `json:"duration_ns"`
+	Reason     string     `json:"reason"`
+	Site       string     `json:"site,omitempty"`
+	Stack      *StackJSON `json:"stack,omitempty"`
 }
 
 // JSONFormatter handles JSON output
 type JSONFormatter struct {
-	writer io.Writer
+	writer  io.Writer
+	compact bool
+	events  int
+}
+
+func init() {
+	Register("json", func(w io.Writer, opts Options) Formatter {
+		f := NewJSONFormatter(w)
+		f.SetCompact(opts.Compact)
+		f.SetEvents(opts.Events)
+		return f
+	})
 }
 
 // NewJSONFormatter creates a JSON formatter
@@ -47,24 +349,74 @@ func NewJSONFormatter(w io.Writer) *JSONFormatter {
 	return &JSONFormatter{writer: w}
 }
 
-// FormatSummary outputs the summary as JSON
-func (f *JSONFormatter) FormatSummary(summary *model.Summary) error {
-	output := f.convertToJSON(summary)
+// SetCompact writes each document on a single line without indentation
+func (f *JSONFormatter) SetCompact(compact bool) {
+	f.compact = compact
+}
 
+// SetEvents includes up to n blocking events in goroutine details, keeping
+// the longest in chronological order; n < 0 includes every event, 0 none
+func (f *JSONFormatter) SetEvents(n int) {
+	f.events = n
+}
+
+// encoder returns an encoder on the writer, indented unless compact
+func (f *JSONFormatter) encoder() *json.Encoder {
 	encoder := json.NewEncoder(f.writer)
-	encoder.SetIndent("", "  ")
+	if !f.compact {
+		encoder.SetIndent("", "  ")
+	}
+	return encoder
+}
 
-	return encoder.Encode(output)
+// FormatSummary outputs the summary as JSON
+func (f *JSONFormatter) FormatSummary(summary *model.Summary) error {
+	output := f.convertToJSON(summary)
+	return f.encoder().Encode(output)
 }
 
 // FormatGoroutineDetail outputs goroutine details as JSON
 func (f *JSONFormatter) FormatGoroutineDetail(g *model.GoroutineInfo) error {
 	output := f.convertGoroutineToJSON(g, true)
+	return f.encoder().Encode(output)
+}
 
-	encoder := json.NewEncoder(f.writer)
-	encoder.SetIndent("", "  ")
+// InsightJSON is one narrative insight
+type InsightJSON struct {
+	Title       string `json:"title"`
+	Observation string `json:"observation"`
+	Suggestion  string `json:"suggestion"`
+	Severity    string `json:"severity"`
+	DocLink     string `json:"doc_link,omitempty"`
+}
 
-	return encoder.Encode(output)
+// FormatInsights outputs the narrative insights as an array
+func (f *JSONFormatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
+	output := make([]InsightJSON, 0, len(insights))
+	for _, in := range insights {
+		output = append(output, newInsightJSON(in))
+	}
+	return f.encoder().Encode(output)
+}
+
+// newInsightJSON converts a narrative insight for serialization
+func newInsightJSON(in analyzer.NarrativeInsight) InsightJSON {
+	return InsightJSON{
+		Title:       in.Title,
+		Observation: in.Observation,
+		Suggestion:  in.Suggestion,
+		Severity:    in.Severity,
+		DocLink:     in.DocLink,
+	}
+}
+
+// FormatGoroutineComparison outputs the details of several goroutines as an array
+func (f *JSONFormatter) FormatGoroutineComparison(goroutines []*model.GoroutineInfo) error {
+	output := make([]GoroutineJSON, 0, len(goroutines))
+	for _, g := range goroutines {
+		output = append(output, f.convertGoroutineToJSON(g, true))
+	}
+	return f.encoder().Encode(output)
 }
 
 // convertToJSON transforms model.Summary to JSONOutput
@@ -72,12 +424,68 @@ func (f *JSONFormatter) convertToJSON(summary *model.Summary) *JSONOutput {
 	output := &JSONOutput{
 		TotalGoroutines:   summary.TotalGoroutines,
 		PeakGoroutines:    summary.PeakGoroutines,
+		AliveAtEnd:        summary.AliveAtEnd,
+		GOMAXPROCS:        summary.GOMAXPROCS,
+		PeakRunnable:      summary.PeakRunnable,
 		TotalBlockedTime:  formatDurationJSON(summary.TotalBlockedTime),
 		TotalRuntime:      formatDurationJSON(summary.TotalRuntime),
-		BlockingBreakdown: make(map[string]BlockingReasonStats),
+		TotalRunnable:     formatDurationJSON(summary.TotalRunnable),
+		WallTime:          formatDurationJSON(summary.WallTime),
+		BlockingBreakdown: make(BreakdownJSON),
+		BlockingGini:      summary.BlockingGini,
+		TotalMigrations:   summary.TotalMigrations,
 		TopBlocked:        make([]GoroutineJSON, 0, len(summary.TopBlocked)),
+		Verdict:           analyzer.Verdict(summary),
 		PerformanceIssues: summary.HasPerformanceIssues,
 		Issues:            summary.Issues,
+		Incomplete:        summary.Incomplete,
+		PrunedGoroutines:  summary.PrunedGoroutines,
+	}
+
+	if t := summary.Trace; t != nil {
+		output.Trace = &TraceJSON{
+			File:          t.File,
+			SizeBytes:     t.Size,
+			Duration:      formatDurationJSON(summary.WallTime),
+			GoVersion:     t.GoVersion,
+			GOMAXPROCS:    t.GOMAXPROCS,
+			Events:        t.Events,
+			SkippedEvents: t.SkippedEvents,
+		}
+	}
+
+	if summary.NoiseFloor > 0 {
+		output.Noise = &NoiseJSON{
+			Floor:  formatDurationJSON(summary.NoiseFloor),
+			Events: summary.NoiseEvents,
+			Time:   formatDurationJSON(summary.NoiseTime),
+		}
+	}
+
+	if w := summary.Window; w != nil {
+		output.Window = &WindowJSON{Since: formatDurationJSON(w.Since)}
+		if w.Until > 0 {
+			output.Window.Until = formatDurationJSON(w.Until)
+		}
+	}
+
+	if summary.FinalizerRuntime > 0 {
+		output.FinalizerRuntime = formatDurationJSON(summary.FinalizerRuntime)
+	}
+
+	output.StateSplit.Running, output.StateSplit.Runnable, output.StateSplit.Blocked = summary.StateSplit()
+
+	output.NeverBlocked = NeverBlockedJSON{
+		Goroutines: summary.NeverBlocked,
+		Runtime:    formatDurationJSON(summary.NeverBlockedRuntime),
+	}
+	if summary.TotalRuntime > 0 {
+		output.NeverBlocked.RuntimePct = float64(summary.NeverBlockedRuntime) / float64(summary.TotalRuntime) * 100
+	}
+
+	if summary.IdealWallTime > 0 {
+		output.IdealWallTime = formatDurationJSON(summary.IdealWallTime)
+		output.OverheadFactor = float64(summary.WallTime) / float64(summary.IdealWallTime)
 	}
 
 	for reason, duration := range summary.BlockingBreakdown {
@@ -91,6 +499,181 @@ func (f *JSONFormatter) convertToJSON(summary *model.Summary) *JSONOutput {
 		output.TopBlocked = append(output.TopBlocked, f.convertGoroutineToJSON(g, false))
 	}
 
+	if len(summary.SelectOutcomes) > 0 {
+		output.SelectOutcomes = make(map[string]int, len(summary.SelectOutcomes))
+		for c, n := range summary.SelectOutcomes {
+			output.SelectOutcomes[c.String()] = n
+		}
+	}
+
+	if c := summary.ConcurrencyCap; c != nil {
+		output.ConcurrencyCap = &ConcurrencyCapJSON{
+			PeakGoroutines: c.PeakGoroutines,
+			Parallelism:    c.Parallelism,
+			Recommended:    c.Recommended,
+		}
+	}
+
+	for _, c := range summary.Cliffs {
+		cj := CliffJSON{
+			Offset:   formatDurationJSON(c.At),
+			Duration: formatDurationJSON(c.Duration),
+			From:     c.From,
+			To:       c.To,
+		}
+		if c.UnblockCount > 0 {
+			cj.UnblockReason = c.UnblockReason.String()
+			cj.UnblockCount = c.UnblockCount
+		}
+		output.Cliffs = append(output.Cliffs, cj)
+	}
+
+	if gc := summary.GC; gc != nil && gc.Cycles > 0 {
+		output.GC = &GCJSON{
+			Cycles:          gc.Cycles,
+			CyclesPerSecond: gc.PerSecond,
+			Pauses:          gc.Pauses,
+			AvgPause:        formatDurationJSON(gc.AvgPause),
+			MaxPause:        formatDurationJSON(gc.MaxPause),
+		}
+	}
+
+	if o := summary.BlockedOverlap; o != nil {
+		output.BlockedOverlap = &BlockedOverlapJSON{
+			OverlapTime:    formatDurationJSON(o.OverlapTime),
+			OverlapShare:   o.OverlapShare,
+			MeanConcurrent: o.MeanConcurrent,
+			Peak:           o.Peak,
+			PeakOffset:     formatDurationJSON(o.PeakAt),
+			Windows:        o.Windows,
+		}
+	}
+
+	if t := summary.BlockingTiming; t != nil {
+		output.BlockingTiming = &BlockingTimingJSON{
+			Centroid:    t.Centroid,
+			ThirdsPct:   t.Thirds,
+			Phase:       t.Phase,
+			Description: analyzer.DescribeBlockingTiming(t),
+		}
+	}
+
+	for _, c := range summary.ChannelImbalances {
+		output.ChannelImbalances = append(output.ChannelImbalances, ImbalanceJSON{
+			Site:        c.Site,
+			Reason:      c.Reason.String(),
+			Blocked:     c.Blocked,
+			Peers:       c.Peers,
+			BlockedTime: formatDurationJSON(c.BlockedTime),
+		})
+	}
+
+	if g := summary.Growth; g != nil {
+		output.Growth = &GrowthJSON{
+			From:      g.From,
+			To:        g.To,
+			Growth:    g.To - g.From,
+			PerSecond: g.PerSecond,
+			R2:        g.R2,
+		}
+	}
+
+	if c := summary.Churn; c != nil {
+		output.Churn = &ChurnJSON{
+			Switches:      c.Switches,
+			PerSecond:     c.PerSecond,
+			PerProcSecond: c.PerProcSecond,
+			AvgRunSlice:   formatDurationJSON(c.AvgRunSlice),
+			Thrashing:     c.Thrashing,
+		}
+	}
+
+	if rs := summary.RunnableStarvation; rs != nil {
+		output.Starvation = &StarvationJSON{
+			Saturated:     rs.Saturated,
+			SaturatedTime: formatDurationJSON(rs.SaturatedTime),
+			Anomalous:     rs.Anomalous,
+			AnomalousTime: formatDurationJSON(rs.AnomalousTime),
+		}
+		if w := rs.WorstAnomaly; w != nil {
+			output.Starvation.WorstAnomaly = &RunnableWaitJSON{
+				GoroutineID: w.GoroutineID,
+				Offset:      formatDurationJSON(w.Start),
+				Duration:    formatDurationJSON(w.Duration),
+				IdleShare:   w.IdleShare,
+			}
+		}
+	}
+
+	for _, c := range summary.BlockingChains {
+		reasons := make([]string, len(c.Reasons))
+		for i, r := range c.Reasons {
+			reasons[i] = r.String()
+		}
+		output.BlockingChains = append(output.BlockingChains, ChainJSON{
+			Goroutines:   c.Goroutines,
+			Reasons:      reasons,
+			RootCause:    c.RootCause(),
+			Occurrences:  c.Occurrences,
+			TotalLatency: formatDurationJSON(c.TotalLatency),
+			MaxLatency:   formatDurationJSON(c.MaxLatency),
+		})
+	}
+
+	if cp := summary.CriticalPath; cp != nil {
+		cj := &CriticalPathJSON{
+			Root:     cp.Root,
+			Blocked:  formatDurationJSON(cp.Blocked),
+			OffPath:  formatDurationJSON(cp.OffPath),
+			ByReason: make(map[string]string, len(cp.ByReason)),
+		}
+		for r, d := range cp.ByReason {
+			cj.ByReason[r.String()] = formatDurationJSON(d)
+		}
+		for _, share := range cp.Goroutines {
+			cj.Goroutines = append(cj.Goroutines, GoroutineShare{ID: share.ID, Duration: formatDurationJSON(share.Duration)})
+		}
+		output.CriticalPath = cj
+	}
+
+	for _, c := range summary.ReasonCycles {
+		output.ReasonCycles = append(output.ReasonCycles, ReasonCycleJSON{
+			GoroutineID:  c.GoroutineID,
+			Label:        c.Label,
+			Reasons:      []string{c.A.String(), c.B.String()},
+			Alternations: c.Alternations,
+			Share:        c.Share,
+		})
+	}
+
+	for _, g := range summary.WorstStalls {
+		output.WorstStalls = append(output.WorstStalls, BlockJSON{
+			GoroutineID: g.ID,
+			Reason:      g.LongestBlock.Reason.String(),
+			Duration:    formatDurationJSON(g.LongestBlock.Duration),
+			Offset:      formatDurationJSON(g.LongestBlock.StartTime - summary.TraceStart),
+		})
+	}
+
+	for _, g := range summary.MigrationHeavy {
+		output.MigrationHeavy = append(output.MigrationHeavy, MigrationJSON{
+			GoroutineID: g.ID,
+			Label:       g.Label,
+			Migrations:  g.Migrations,
+			Schedules:   g.Schedules,
+		})
+	}
+
+	for _, b := range summary.LongestBlocks {
+		output.LongestBlocks = append(output.LongestBlocks, BlockJSON{
+			GoroutineID: b.GoroutineID,
+			Reason:      b.Event.Reason.String(),
+			Duration:    formatDurationJSON(b.Event.Duration),
+			Offset:      formatDurationJSON(b.Event.StartTime - summary.TraceStart),
+			Stack:       stackJSON(b.Event.Stack),
+		})
+	}
+
 	return output
 }
 
@@ -98,25 +681,63 @@ func (f *JSONFormatter) convertToJSON(summary *model.Summary) *JSONOutput {
 func (f *JSONFormatter) convertGoroutineToJSON(g *model.GoroutineInfo, includeDetails bool) GoroutineJSON {
 	gj := GoroutineJSON{
 		ID:             g.ID,
+		Label:          g.Label,
+		Name:           g.Name,
 		TotalBlocked:   formatDurationJSON(g.TotalBlocked),
 		TotalRuntime:   formatDurationJSON(g.TotalRuntime),
 		TotalRunnable:  formatDurationJSON(g.TotalRunnable),
 		PrimaryReason:  getPrimaryReason(g).String(),
-		BlockingEvents: len(g.BlockingEvents),
+		BlockingEvents: len(g.BlockingEvents) + g.DroppedEvents,
+		LongestBlock:   formatDurationJSON(g.LongestBlock.Duration),
+		Migrations:     g.Migrations,
 	}
 
 	if includeDetails {
+		gj.CreationStack = g.CreationStack
+		gj.CreationHash = g.CreationHash
+		gj.Preexisting = g.Preexisting
+		if g.TerminatedAt > 0 {
+			gj.Lifetime = formatDurationJSON(g.Lifetime())
+		}
 		gj.BlockingByReason = make(map[string]string)
 		for reason, duration := range g.BlockingByReason {
 			if duration > 0 {
 				gj.BlockingByReason[reason.String()] = formatDurationJSON(duration)
 			}
 		}
+		if f.events != 0 {
+			for _, ev := range boundedEvents(g.BlockingEvents, f.events) {
+				gj.Events = append(gj.Events, EventJSON{
+					StartNs:    int64(ev.StartTime),
+					DurationNs: int64(ev.Duration),
+					Reason:     ev.Reason.String(),
+					Site:       ev.Site(),
+					Stack:      stackJSON(ev.Stack),
+				})
+			}
+		}
 	}
 
 	return gj
 }
 
+// boundedEvents returns the n longest events in chronological order, or all
+// of them when n < 0
+func boundedEvents(events []model.BlockingEvent, n int) []model.BlockingEvent {
+	if n < 0 || len(events) <= n {
+		return events
+	}
+	kept := slices.Clone(events)
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].Duration > kept[j].Duration
+	})
+	kept = kept[:n]
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].StartTime < kept[j].StartTime
+	})
+	return kept
+}
+
 // formatDurationJSON converts duration to string for JSON
 func formatDurationJSON(d time.Duration) string {
 	if d == 0 {
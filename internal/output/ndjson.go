@@ -0,0 +1,78 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+func init() {
+	Register("ndjson", func(w io.Writer, opts Options) Formatter {
+		f := NewNDJSONFormatter(w)
+		f.json.SetEvents(opts.Events)
+		return f
+	})
+}
+
+// NDJSONFormatter writes newline-delimited JSON: every call emits complete,
+// timestamped lines, so a consumer reading a pipe (e.g. from analyze --watch)
+// can process each snapshot as it arrives
+type NDJSONFormatter struct {
+	json *JSONFormatter
+	now  func() time.Time
+}
+
+// NewNDJSONFormatter creates an NDJSON formatter
+func NewNDJSONFormatter(w io.Writer) *NDJSONFormatter {
+	j := NewJSONFormatter(w)
+	j.SetCompact(true)
+	return &NDJSONFormatter{json: j, now: time.Now}
+}
+
+// summaryLine is one summary snapshot
+type summaryLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	*JSONOutput
+}
+
+// goroutineLine is one goroutine's details
+type goroutineLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	GoroutineJSON
+}
+
+// insightLine is one narrative insight
+type insightLine struct {
+	Timestamp time.Time `json:"timestamp"`
+	InsightJSON
+}
+
+// FormatSummary writes the summary as a single line
+func (f *NDJSONFormatter) FormatSummary(summary *model.Summary) error {
+	return f.encoder().Encode(summaryLine{Timestamp: f.now(), JSONOutput: f.json.convertToJSON(summary)})
+}
+
+// FormatGoroutineDetail writes the goroutine's details as a single line
+func (f *NDJSONFormatter) FormatGoroutineDetail(g *model.GoroutineInfo) error {
+	return f.encoder().Encode(goroutineLine{Timestamp: f.now(), GoroutineJSON: f.json.convertGoroutineToJSON(g, true)})
+}
+
+// FormatInsights writes one line per insight, all with the same timestamp
+func (f *NDJSONFormatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
+	now := f.now()
+	enc := f.encoder()
+	for _, in := range insights {
+		if err := enc.Encode(insightLine{Timestamp: now, InsightJSON: newInsightJSON(in)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encoder returns a compact encoder on the writer
+func (f *NDJSONFormatter) encoder() *json.Encoder {
+	return f.json.encoder()
+}
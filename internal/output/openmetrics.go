@@ -0,0 +1,142 @@
+package output
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+func init() {
+	Register("openmetrics", func(w io.Writer, opts Options) Formatter {
+		f := NewOpenMetricsFormatter(w)
+		f.SetExemplars(opts.Exemplars)
+		return f
+	})
+}
+
+// OpenMetricsFormatter writes the analysis in the OpenMetrics text format,
+// for scraping or pushing into a metrics backend. With exemplars on, each
+// per-reason blocking counter carries a sample goroutine ID, so a dashboard
+// can jump from a spike to a goroutine to inspect.
+type OpenMetricsFormatter struct {
+	writer    io.Writer
+	exemplars bool
+}
+
+// NewOpenMetricsFormatter creates an OpenMetrics formatter
+func NewOpenMetricsFormatter(w io.Writer) *OpenMetricsFormatter {
+	return &OpenMetricsFormatter{writer: w}
+}
+
+// SetExemplars attaches a sample goroutine to each blocking counter
+func (f *OpenMetricsFormatter) SetExemplars(on bool) {
+	f.exemplars = on
+}
+
+// FormatSummary writes the trace-wide metrics
+func (f *OpenMetricsFormatter) FormatSummary(summary *model.Summary) error {
+	w := bufio.NewWriter(f.writer)
+
+	metric(w, "goschedviz_goroutines", "gauge", "Goroutines seen in the trace.")
+	fmt.Fprintf(w, "goschedviz_goroutines %d\n", summary.TotalGoroutines)
+	metric(w, "goschedviz_wall_seconds", "gauge", "Span of the trace.")
+	fmt.Fprintf(w, "goschedviz_wall_seconds %s\n", seconds(summary.WallTime))
+	metric(w, "goschedviz_runtime_seconds", "counter", "Time goroutines spent running.")
+	fmt.Fprintf(w, "goschedviz_runtime_seconds_total %s\n", seconds(summary.TotalRuntime))
+	metric(w, "goschedviz_runnable_seconds", "counter", "Time goroutines spent runnable, waiting for a P.")
+	fmt.Fprintf(w, "goschedviz_runnable_seconds_total %s\n", seconds(summary.TotalRunnable))
+
+	metric(w, "goschedviz_blocked_seconds", "counter", "Time goroutines spent blocked, by reason.")
+	reasons := make([]model.BlockingReason, 0, len(summary.BlockingBreakdown))
+	for reason := range summary.BlockingBreakdown {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "goschedviz_blocked_seconds_total{reason=%s} %s", labelValue(reason.String()), seconds(summary.BlockingBreakdown[reason]))
+		if f.exemplars {
+			f.writeExemplar(w, summary, reason)
+		}
+		fmt.Fprintln(w)
+	}
+
+	metric(w, "goschedviz_performance_issues", "gauge", "Performance issues detected.")
+	fmt.Fprintf(w, "goschedviz_performance_issues %d\n", len(summary.Issues))
+	fmt.Fprintln(w, "# EOF")
+	return w.Flush()
+}
+
+// writeExemplar appends the most blocked goroutine for reason among the
+// ones the summary ranks, timestamped at its longest block when the trace
+// has a wall clock
+func (f *OpenMetricsFormatter) writeExemplar(w io.Writer, summary *model.Summary, reason model.BlockingReason) {
+	var sample *model.GoroutineInfo
+	for _, g := range slices.Concat(summary.TopBlocked, summary.WorstStalls) {
+		if d := g.BlockingByReason[reason]; d > 0 && (sample == nil || d > sample.BlockingByReason[reason]) {
+			sample = g
+		}
+	}
+	if sample == nil {
+		return
+	}
+
+	fmt.Fprintf(w, " # {goroutine_id=\"%d\"} %s", sample.ID, seconds(sample.BlockingByReason[reason]))
+	if summary.Clock != nil && sample.LongestBlock.Reason == reason && sample.LongestBlock.Duration > 0 {
+		at := summary.Clock.WallTime(sample.LongestBlock.StartTime)
+		fmt.Fprintf(w, " %s", strconv.FormatFloat(float64(at.UnixNano())/1e9, 'f', 3, 64))
+	}
+}
+
+// FormatGoroutineDetail writes one goroutine's time per state and reason
+func (f *OpenMetricsFormatter) FormatGoroutineDetail(g *model.GoroutineInfo) error {
+	w := bufio.NewWriter(f.writer)
+	id := labelValue(strconv.FormatUint(g.ID, 10))
+
+	metric(w, "goschedviz_goroutine_runtime_seconds", "counter", "Time the goroutine spent running.")
+	fmt.Fprintf(w, "goschedviz_goroutine_runtime_seconds_total{goroutine_id=%s} %s\n", id, seconds(g.TotalRuntime))
+	metric(w, "goschedviz_goroutine_blocked_seconds", "counter", "Time the goroutine spent blocked, by reason.")
+	reasons := make([]model.BlockingReason, 0, len(g.BlockingByReason))
+	for reason := range g.BlockingByReason {
+		reasons = append(reasons, reason)
+	}
+	slices.Sort(reasons)
+	for _, reason := range reasons {
+		fmt.Fprintf(w, "goschedviz_goroutine_blocked_seconds_total{goroutine_id=%s,reason=%s} %s\n", id, labelValue(reason.String()), seconds(g.BlockingByReason[reason]))
+	}
+	fmt.Fprintln(w, "# EOF")
+	return w.Flush()
+}
+
+// FormatInsights writes each insight as an info metric
+func (f *OpenMetricsFormatter) FormatInsights(insights []analyzer.NarrativeInsight) error {
+	w := bufio.NewWriter(f.writer)
+	metric(w, "goschedviz_insight", "info", "Insights drawn from the trace.")
+	for _, in := range insights {
+		fmt.Fprintf(w, "goschedviz_insight_info{title=%s,severity=%s} 1\n", labelValue(in.Title), labelValue(in.Severity))
+	}
+	fmt.Fprintln(w, "# EOF")
+	return w.Flush()
+}
+
+// metric writes a metric family's TYPE and HELP lines
+func metric(w io.Writer, name, typ, help string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n# HELP %s %s\n", name, typ, name, help)
+}
+
+// seconds formats a duration as OpenMetrics seconds
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'g', -1, 64)
+}
+
+// labelValue quotes a label value, escaping as OpenMetrics requires
+func labelValue(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,149 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// PerfettoFormatter renders goroutine state intervals as Chrome Trace Event
+// JSON, which Perfetto and chrome://tracing load directly
+type PerfettoFormatter struct {
+	writer     io.Writer
+	goroutines map[uint64]*model.GoroutineInfo
+}
+
+func init() {
+	RegisterExport("perfetto", func(w io.Writer, _ Options) Formatter {
+		return NewPerfettoFormatter(w)
+	})
+}
+
+// NewPerfettoFormatter creates a Perfetto formatter
+func NewPerfettoFormatter(w io.Writer) *PerfettoFormatter {
+	return &PerfettoFormatter{writer: w}
+}
+
+// SetGoroutines provides the goroutines whose state intervals are exported
+func (f *PerfettoFormatter) SetGoroutines(goroutines map[uint64]*model.GoroutineInfo) {
+	f.goroutines = goroutines
+}
+
+// FormatSummary outputs the timeline of the goroutines, relative to the
+// summary's trace start
+func (f *PerfettoFormatter) FormatSummary(summary *model.Summary) error {
+	return f.FormatTimeline(f.goroutines, summary.TraceStart)
+}
+
+// FormatGoroutineDetail is not supported: the timeline spans every goroutine
+func (f *PerfettoFormatter) FormatGoroutineDetail(*model.GoroutineInfo) error {
+	return exportOnly("perfetto")
+}
+
+// FormatInsights is not supported: the timeline spans every goroutine
+func (f *PerfettoFormatter) FormatInsights([]analyzer.NarrativeInsight) error {
+	return exportOnly("perfetto")
+}
+
+// traceEvent is one entry of the Chrome Trace Event format; ts and dur are
+// in microseconds
+type traceEvent struct {
+	Name  string            `json:"name"`
+	Phase string            `json:"ph"`
+	TS    float64           `json:"ts"`
+	Dur   float64           `json:"dur,omitempty"`
+	PID   int               `json:"pid"`
+	TID   uint64            `json:"tid"`
+	Args  map[string]string `json:"args,omitempty"`
+}
+
+// Categories become Perfetto processes: running, runnable, then one per
+// blocking reason
+const (
+	pidRunning = iota + 1
+	pidRunnable
+	pidBlocked // pidBlocked + reason for each blocking reason
+)
+
+// FormatTimeline outputs one duration event per recorded state interval, with
+// the category as pid and the goroutine ID as tid. Times are relative to
+// traceStart.
+func (f *PerfettoFormatter) FormatTimeline(goroutines map[uint64]*model.GoroutineInfo, traceStart time.Duration) error {
+	ids := make([]uint64, 0, len(goroutines))
+	for id := range goroutines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	events := make([]traceEvent, 0)
+	categories := make(map[int]string)
+	for _, id := range ids {
+		g := goroutines[id]
+
+		// Blocked intervals start exactly where their blocking event does
+		reasons := make(map[time.Duration]model.BlockingReason, len(g.BlockingEvents))
+		for _, ev := range g.BlockingEvents {
+			reasons[ev.StartTime] = ev.Reason
+		}
+
+		threads := make(map[int]bool)
+		for _, iv := range g.Intervals {
+			pid, name := pidRunning, iv.State.String()
+			switch iv.State {
+			case model.StateRunnable:
+				pid = pidRunnable
+			case model.StateBlocked:
+				pid = pidBlocked
+				if reason, ok := reasons[iv.Start]; ok {
+					pid += int(reason)
+					name = reason.String()
+				}
+			}
+			categories[pid] = name
+			threads[pid] = true
+
+			events = append(events, traceEvent{
+				Name:  name,
+				Phase: "X",
+				TS:    microseconds(iv.Start - traceStart),
+				Dur:   microseconds(iv.End - iv.Start),
+				PID:   pid,
+				TID:   g.ID,
+			})
+		}
+
+		for _, pid := range slices.Sorted(maps.Keys(threads)) {
+			events = append(events, traceEvent{
+				Name:  "thread_name",
+				Phase: "M",
+				PID:   pid,
+				TID:   g.ID,
+				Args:  map[string]string{"name": g.DisplayName()},
+			})
+		}
+	}
+
+	for _, pid := range slices.Sorted(maps.Keys(categories)) {
+		events = append(events, traceEvent{
+			Name:  "process_name",
+			Phase: "M",
+			PID:   pid,
+			Args:  map[string]string{"name": categories[pid]},
+		})
+	}
+
+	return json.NewEncoder(f.writer).Encode(struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}{events})
+}
+
+// microseconds converts d to the fractional microseconds the format expects
+func microseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Microsecond)
+}
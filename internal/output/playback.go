@@ -0,0 +1,215 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+const (
+	// playbackFrame is the wall-clock delay between animation frames
+	playbackFrame = 100 * time.Millisecond
+
+	// playbackSteps is how many frames a full pass over the trace takes
+	playbackSteps = 200
+
+	// playbackColumns and maxPlaybackCells bound the goroutine grid
+	playbackColumns  = 60
+	maxPlaybackCells = 1200
+)
+
+var (
+	cellRunning  = lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575"))
+	cellRunnable = lipgloss.NewStyle().Foreground(lipgloss.Color("#F4D03F"))
+	cellBlocked  = lipgloss.NewStyle().Foreground(lipgloss.Color("#EF3340"))
+	cellGone     = lipgloss.NewStyle().Foreground(lipgloss.Color("#3A3A3A"))
+)
+
+// playbackTickMsg advances the playhead; gen ties it to the play session that
+// scheduled it so a pause/resume doesn't start a second tick loop
+type playbackTickMsg struct {
+	gen int
+}
+
+// playback is the state of the explorer's trace replay
+type playback struct {
+	goroutines []*model.GoroutineInfo
+	start, end time.Duration
+	step       time.Duration
+	playhead   time.Duration
+	playing    bool
+	gen        int
+}
+
+// newPlayback prepares a replay over the goroutines' recorded intervals; ok is
+// false when the trace was parsed without them
+func newPlayback(goroutines map[uint64]*model.GoroutineInfo) (p playback, ok bool) {
+	first := true
+	for _, g := range goroutines {
+		if len(g.Intervals) == 0 {
+			continue
+		}
+		if first || g.Intervals[0].Start < p.start {
+			p.start = g.Intervals[0].Start
+		}
+		if last := g.Intervals[len(g.Intervals)-1].End; first || last > p.end {
+			p.end = last
+		}
+		first = false
+		p.goroutines = append(p.goroutines, g)
+	}
+	if first || p.end <= p.start {
+		return p, false
+	}
+
+	sort.Slice(p.goroutines, func(i, j int) bool {
+		return p.goroutines[i].ID < p.goroutines[j].ID
+	})
+	p.step = max((p.end-p.start)/playbackSteps, 1)
+	p.playhead = p.start
+	return p, true
+}
+
+// tick schedules the next animation frame
+func (p *playback) tick() tea.Cmd {
+	gen := p.gen
+	return tea.Tick(playbackFrame, func(time.Time) tea.Msg {
+		return playbackTickMsg{gen: gen}
+	})
+}
+
+// toggle pauses or resumes playback; resuming from the end restarts it
+func (p *playback) toggle() tea.Cmd {
+	p.playing = !p.playing
+	if !p.playing {
+		return nil
+	}
+	if p.playhead >= p.end {
+		p.playhead = p.start
+	}
+	p.gen++
+	return p.tick()
+}
+
+// seek moves the playhead by n steps, pausing playback
+func (p *playback) seek(n int) {
+	p.playing = false
+	p.playhead = max(p.start, min(p.end, p.playhead+p.step*time.Duration(n)))
+}
+
+// advance handles a tick, returning the next one while still playing
+func (p *playback) advance(msg playbackTickMsg) tea.Cmd {
+	if !p.playing || msg.gen != p.gen {
+		return nil
+	}
+	p.playhead += p.step
+	if p.playhead >= p.end {
+		p.playhead = p.end
+		p.playing = false
+		return nil
+	}
+	return p.tick()
+}
+
+// updatePlayback handles keys while the explorer is in playback
+func (m ExplorerModel) updatePlayback(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case " ":
+		return m, m.playback.toggle()
+	case "left", "h":
+		m.playback.seek(-1)
+	case "right", "l":
+		m.playback.seek(1)
+	case "pgup", "[":
+		m.playback.seek(-playbackSteps / 10)
+	case "pgdown", "]":
+		m.playback.seek(playbackSteps / 10)
+	case "home", "g":
+		m.playback.seek(-playbackSteps)
+	case "esc":
+		m.playback.playing = false
+		m.state = stateTable
+	}
+	return m, nil
+}
+
+// playbackView renders the timeline cursor and a grid of goroutine states at
+// the playhead
+func (m ExplorerModel) playbackView() string {
+	p := m.playback
+
+	banner := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Bold(true).
+		Render(" PLAYBACK ")
+
+	status := "⏸ paused"
+	if p.playing {
+		status = "▶ playing"
+	}
+	span := p.end - p.start
+	pos := int(float64(p.playhead-p.start) / float64(span) * playbackColumns)
+	pos = min(pos, playbackColumns-1)
+	timeline := strings.Repeat("─", pos) + "●" + strings.Repeat("─", playbackColumns-1-pos)
+
+	var running, runnable, blocked int
+	var grid strings.Builder
+	for i, g := range p.goroutines {
+		state, alive := g.StateAt(p.playhead)
+		if alive {
+			switch state {
+			case model.StateRunning:
+				running++
+			case model.StateRunnable:
+				runnable++
+			case model.StateBlocked:
+				blocked++
+			}
+		}
+		if i >= maxPlaybackCells {
+			continue
+		}
+		if i > 0 && i%playbackColumns == 0 {
+			grid.WriteString("\n")
+		}
+		switch {
+		case !alive:
+			grid.WriteString(cellGone.Render("·"))
+		case state == model.StateRunning:
+			grid.WriteString(cellRunning.Render("█"))
+		case state == model.StateRunnable:
+			grid.WriteString(cellRunnable.Render("█"))
+		default:
+			grid.WriteString(cellBlocked.Render("█"))
+		}
+	}
+	if extra := len(p.goroutines) - maxPlaybackCells; extra > 0 {
+		fmt.Fprintf(&grid, "\n… %d more goroutines not shown", extra)
+	}
+
+	header := fmt.Sprintf("%s  %s / %s\n%s\n%s %d running  %s %d runnable  %s %d blocked",
+		status,
+		formatDuration(p.playhead-p.start),
+		formatDuration(span),
+		timeline,
+		cellRunning.Render("█"), running,
+		cellRunnable.Render("█"), runnable,
+		cellBlocked.Render("█"), blocked,
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		banner,
+		"\n",
+		header,
+		"",
+		baseStyle.Render(grid.String()),
+		helpStyle.Render(" • space: play/pause • ←/→: step • [/]: jump • g: rewind • esc: back"),
+	)
+}
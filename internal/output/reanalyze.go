@@ -0,0 +1,96 @@
+package output
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+	"github.com/goschedviz/goschedviz/internal/traceparser"
+)
+
+// noiseFloorSteps are the noise floors the explorer's n key cycles through
+var noiseFloorSteps = []time.Duration{0, 10 * time.Microsecond, 100 * time.Microsecond, time.Millisecond}
+
+// AnalysisToggles are the analyzer options the explorer can change without
+// re-parsing the trace
+type AnalysisToggles struct {
+	ExcludeMain bool
+	ExcludeIdle bool
+	NoiseFloor  time.Duration
+
+	// EventsCapped disables the noise floor: the parser dropped the short
+	// events it would have to subtract
+	EventsCapped bool
+}
+
+// String lists the active toggles, e.g. "main excluded, noise floor 10µs"
+func (t AnalysisToggles) String() string {
+	var parts []string
+	if t.ExcludeMain {
+		parts = append(parts, "main excluded")
+	}
+	if t.ExcludeIdle {
+		parts = append(parts, "idle excluded")
+	}
+	if t.NoiseFloor > 0 {
+		parts = append(parts, "noise floor "+formatDuration(t.NoiseFloor))
+	}
+	if len(parts) == 0 {
+		return "defaults"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nextNoiseFloor returns the step after the current noise floor, wrapping to
+// none; a floor set from the command line that isn't a step goes to none
+func (t AnalysisToggles) nextNoiseFloor() time.Duration {
+	i := slices.Index(noiseFloorSteps, t.NoiseFloor)
+	if i < 0 {
+		return 0
+	}
+	return noiseFloorSteps[(i+1)%len(noiseFloorSteps)]
+}
+
+// Reanalyzer re-runs the analysis of an already parsed trace with the given
+// toggles, returning the summary and the goroutines it covered
+type Reanalyzer func(t AnalysisToggles) (*model.Summary, map[uint64]*model.GoroutineInfo)
+
+// newReanalyzer analyzes result with the trace-level data it carries
+func newReanalyzer(result *traceparser.ParseResult) Reanalyzer {
+	return func(t AnalysisToggles) (*model.Summary, map[uint64]*model.GoroutineInfo) {
+		a := analyzer.NewAnalyzer(result.Goroutines)
+		a.SetGOMAXPROCS(result.GOMAXPROCS)
+		a.SetGCActivity(result.GC)
+		a.SetBusyProcs(result.BusyProcs)
+		a.SetPeakRunnable(result.PeakRunnable)
+		a.SetExcludeMain(t.ExcludeMain)
+		a.SetExcludeIdle(t.ExcludeIdle)
+		a.SetNoiseFloor(t.NoiseFloor)
+		summary := a.Analyze()
+		summary.Incomplete = result.Partial
+		return summary, a.Goroutines()
+	}
+}
+
+// SetReanalyzer lets the explorer re-run the analysis when an option is
+// toggled; current are the toggles the shown summary was made with
+func (m *ExplorerModel) SetReanalyzer(fn Reanalyzer, current AnalysisToggles) {
+	m.reanalyze = fn
+	m.toggles = current
+}
+
+// toggle re-runs the analysis with t and refreshes the table
+func (m *ExplorerModel) toggle(t AnalysisToggles) {
+	if m.reanalyze == nil {
+		m.statusMsg = "re-analysis isn't available for this view"
+		return
+	}
+	start := time.Now()
+	m.toggles = t
+	m.summary, m.goroutines = m.reanalyze(t)
+	m.RefreshTable()
+	m.statusMsg = fmt.Sprintf("✔ re-analyzed in %s: %s", formatDuration(time.Since(start)), t)
+}
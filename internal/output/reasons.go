@@ -0,0 +1,185 @@
+package output
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/goschedviz/goschedviz/internal/model"
+	"github.com/goschedviz/goschedviz/internal/stats"
+)
+
+// maxContributors caps the drill-down list, like rowWindow caps the main table
+const maxContributors = rowWindow
+
+// reasonDrill is the explorer's reason overview and the contributor list of
+// the reason picked from it
+type reasonDrill struct {
+	reasons table.Model
+	order   []model.BlockingReason
+
+	reason       model.BlockingReason
+	contributors table.Model
+	goroutines   []*model.GoroutineInfo
+	total        int
+}
+
+// newReasonDrill lists the trace's blocking reasons, most blocked time first
+func newReasonDrill(summary *model.Summary) reasonDrill {
+	d := reasonDrill{reasons: newDrillTable(), contributors: newDrillTable()}
+	for reason, dur := range summary.BlockingBreakdown {
+		if dur > 0 {
+			d.order = append(d.order, reason)
+		}
+	}
+	sort.Slice(d.order, func(i, j int) bool {
+		a, b := summary.BlockingBreakdown[d.order[i]], summary.BlockingBreakdown[d.order[j]]
+		if a != b {
+			return a > b
+		}
+		return d.order[i] < d.order[j]
+	})
+
+	d.reasons.SetColumns([]table.Column{
+		{Title: "Reason", Width: 20},
+		{Title: "Blocked", Width: 12},
+		{Title: "Share", Width: 8},
+	})
+	rows := make([]table.Row, 0, len(d.order))
+	for _, reason := range d.order {
+		rows = append(rows, table.Row{
+			reason.String(),
+			formatDuration(summary.BlockingBreakdown[reason]),
+			fmt.Sprintf("%.1f%%", summary.BlockingPercent[reason]),
+		})
+	}
+	d.reasons.SetRows(rows)
+	return d
+}
+
+// newDrillTable creates a focused table styled like the main one
+func newDrillTable() table.Model {
+	t := table.New(table.WithFocused(true), table.WithHeight(15))
+	s := table.DefaultStyles()
+	s.Header = s.Header.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(lipgloss.Color("240")).
+		BorderBottom(true).
+		Bold(true)
+	s.Selected = s.Selected.
+		Foreground(lipgloss.Color("229")).
+		Background(lipgloss.Color("#7D56F4")).
+		Bold(true)
+	t.SetStyles(s)
+	return t
+}
+
+// selectReason fills the contributor list with the goroutines blocked on the
+// reason under the cursor, sorted by their time in it
+func (d *reasonDrill) selectReason(goroutines map[uint64]*model.GoroutineInfo) bool {
+	pos := d.reasons.Cursor()
+	if pos < 0 || pos >= len(d.order) {
+		return false
+	}
+	d.reason = d.order[pos]
+	d.goroutines = stats.NewAggregator(goroutines).GetGoroutinesByReason(d.reason, len(goroutines))
+	d.total = len(d.goroutines)
+	if len(d.goroutines) > maxContributors {
+		d.goroutines = d.goroutines[:maxContributors]
+	}
+
+	d.contributors.SetColumns([]table.Column{
+		{Title: "ID", Width: 20},
+		{Title: "In " + d.reason.String(), Width: 20},
+		{Title: "Total Blocked", Width: 14},
+		{Title: "Primary Reason", Width: 20},
+	})
+	rows := make([]table.Row, 0, len(d.goroutines))
+	for _, g := range d.goroutines {
+		rows = append(rows, table.Row{
+			g.DisplayName(),
+			formatDuration(g.BlockingByReason[d.reason]),
+			formatDuration(g.TotalBlocked),
+			getPrimaryBlockingReason(g).String(),
+		})
+	}
+	d.contributors.SetRows(rows)
+	d.contributors.SetCursor(0)
+	return true
+}
+
+// contributor returns the goroutine under the contributor list's cursor
+func (d *reasonDrill) contributor() *model.GoroutineInfo {
+	pos := d.contributors.Cursor()
+	if pos < 0 || pos >= len(d.goroutines) {
+		return nil
+	}
+	return d.goroutines[pos]
+}
+
+// updateReasons handles keys in the reason overview and the contributor list
+func (m ExplorerModel) updateReasons(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch msg.String() {
+	case "esc":
+		if m.state == stateContributors {
+			m.state = stateReasons
+		} else {
+			m.state = stateTable
+		}
+		return m, nil
+	case "enter":
+		if m.state == stateReasons {
+			if m.drill.selectReason(m.goroutines) {
+				m.state = stateContributors
+			}
+			return m, nil
+		}
+		if g := m.drill.contributor(); g != nil {
+			m.selectedID = g.ID
+			m.detailReturn = stateContributors
+			m.state = stateDetail
+			m.statusMsg = ""
+		}
+		return m, nil
+	}
+	if m.state == stateReasons {
+		m.drill.reasons, cmd = m.drill.reasons.Update(msg)
+	} else {
+		m.drill.contributors, cmd = m.drill.contributors.Update(msg)
+	}
+	return m, cmd
+}
+
+// reasonsView renders the reason overview or the selected reason's
+// contributors
+func (m ExplorerModel) reasonsView() string {
+	title, body, help := " BLOCKING REASONS ", m.drill.reasons.View(), " • ↑/↓: navigate • enter: show goroutines • esc: back"
+	info := fmt.Sprintf("\n Reasons: %d | Total Blocked: %s\n", len(m.drill.order), formatDuration(m.summary.TotalBlockedTime))
+	if m.state == stateContributors {
+		title = fmt.Sprintf(" %s CONTRIBUTORS ", m.drill.reason)
+		body = m.drill.contributors.View()
+		help = " • ↑/↓: navigate • enter: inspect • esc: back to reasons"
+		info = fmt.Sprintf("\n Goroutines: %d | In %s: %s\n", m.drill.total, m.drill.reason,
+			formatDuration(m.summary.BlockingBreakdown[m.drill.reason]))
+		if m.drill.total > len(m.drill.goroutines) {
+			info += fmt.Sprintf(" Showing the top %d\n", len(m.drill.goroutines))
+		}
+	}
+
+	banner := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FAFAFA")).
+		Background(lipgloss.Color("#7D56F4")).
+		Padding(0, 1).
+		Bold(true).
+		Render(title)
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		banner,
+		info,
+		baseStyle.Render(body),
+		helpStyle.Render(help),
+	)
+}
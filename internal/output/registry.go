@@ -0,0 +1,105 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+// Formatter renders analysis results in one output format
+type Formatter interface {
+	FormatSummary(summary *model.Summary) error
+	FormatGoroutineDetail(g *model.GoroutineInfo) error
+	FormatInsights(insights []analyzer.NarrativeInsight) error
+}
+
+// Options are the settings a command can pass to any format; each format
+// uses the ones that apply to it
+type Options struct {
+	TimeUnit        TimeUnit
+	BreakdownMinPct float64
+	Clock           *model.ClockReference
+	Compact         bool
+	Exemplars       bool
+	Events          int // blocking events per goroutine detail (-1 for all)
+	TopN            int // blocking events listed in text goroutine details (0 for all)
+}
+
+// Constructor creates a formatter that writes to w
+type Constructor func(w io.Writer, opts Options) Formatter
+
+// formats maps --format names to their constructors; exports marks the
+// ones registered with RegisterExport
+var (
+	formats = map[string]Constructor{}
+	exports = map[string]bool{}
+)
+
+// Register makes a format available to New under name
+func Register(name string, c Constructor) {
+	if _, dup := formats[name]; dup {
+		panic("output: format " + name + " registered twice")
+	}
+	formats[name] = c
+}
+
+// RegisterExport makes an export format available to New under name. Exports
+// render a whole analysis rather than a report, so they only implement
+// FormatSummary and only analyze offers them.
+func RegisterExport(name string, c Constructor) {
+	Register(name, c)
+	exports[name] = true
+}
+
+// GoroutineSetter is implemented by formats that render every goroutine,
+// not just the summary; SetGoroutines is called before FormatSummary
+type GoroutineSetter interface {
+	SetGoroutines(goroutines map[uint64]*model.GoroutineInfo)
+}
+
+// exportOnly is the error export formats return for anything but a summary
+func exportOnly(name string) error {
+	return fmt.Errorf("%s output covers a whole analysis; use it with analyze", name)
+}
+
+// New returns a formatter for the named format
+func New(name string, w io.Writer, opts Options) (Formatter, error) {
+	c, ok := formats[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q (want %s)", name, strings.Join(Formats(), ", "))
+	}
+	return c(w, opts), nil
+}
+
+// Comparer is implemented by formats that can render several goroutines
+// side by side
+type Comparer interface {
+	FormatGoroutineComparison(goroutines []*model.GoroutineInfo) error
+}
+
+// BatchFormatter is implemented by formats that can rank a batch of traces
+type BatchFormatter interface {
+	FormatBatch(entries []BatchEntry) error
+}
+
+// Formats lists the registered report format names in sorted order
+func Formats() []string {
+	var names []string
+	for name := range formats {
+		if !exports[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// ExportFormats lists the registered export format names in sorted order
+func ExportFormats() []string {
+	return slices.Sorted(maps.Keys(exports))
+}
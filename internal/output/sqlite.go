@@ -0,0 +1,192 @@
+package output
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/analyzer"
+	"github.com/goschedviz/goschedviz/internal/model"
+
+	// Registers the pure-Go "sqlite" driver, so no cgo toolchain is needed
+	_ "modernc.org/sqlite"
+)
+
+// reasonColumns names each blocking reason's columns in the analyses table,
+// matching the assertion names (mutex_pct, chan_recv_pct, ...)
+var reasonColumns = map[model.BlockingReason]string{
+	model.BlockChannelSend: "chan_send",
+	model.BlockChannelRecv: "chan_recv",
+	model.BlockMutexLock:   "mutex",
+	model.BlockSyscall:     "syscall",
+	model.BlockGC:          "gc",
+	model.BlockNetwork:     "network",
+	model.BlockSelect:      "select",
+	model.BlockSleep:       "sleep",
+	model.BlockSync:        "sync",
+	model.BlockCgo:         "cgo",
+	model.BlockGCAssist:    "gc_assist",
+	model.BlockFinalizer:   "finalizer",
+}
+
+// sqliteReasons lists the reasons with columns, in declaration order
+func sqliteReasons() []model.BlockingReason {
+	var reasons []model.BlockingReason
+	for _, r := range model.AllBlockingReasons() {
+		if _, ok := reasonColumns[r]; ok {
+			reasons = append(reasons, r)
+		}
+	}
+	return reasons
+}
+
+// sqliteSchema creates the tables on first use: one analyses row per run
+// with a blocked-time and percentage column per reason, a goroutines row per
+// goroutine, and its blocked time per reason in goroutine_blocking
+func sqliteSchema() string {
+	var cols strings.Builder
+	for _, r := range sqliteReasons() {
+		fmt.Fprintf(&cols, ",\n\t%s_ns INTEGER NOT NULL DEFAULT 0,\n\t%s_pct REAL NOT NULL DEFAULT 0", reasonColumns[r], reasonColumns[r])
+	}
+	return `CREATE TABLE IF NOT EXISTS analyses (
+	id INTEGER PRIMARY KEY,
+	trace TEXT NOT NULL,
+	analyzed_at TEXT NOT NULL,
+	go_version TEXT,
+	gomaxprocs INTEGER,
+	goroutines INTEGER NOT NULL,
+	wall_ns INTEGER NOT NULL,
+	runtime_ns INTEGER NOT NULL,
+	runnable_ns INTEGER NOT NULL,
+	blocked_ns INTEGER NOT NULL,
+	verdict TEXT NOT NULL,
+	has_issues INTEGER NOT NULL,
+	incomplete INTEGER NOT NULL` + cols.String() + `
+);
+CREATE TABLE IF NOT EXISTS goroutines (
+	analysis_id INTEGER NOT NULL REFERENCES analyses(id) ON DELETE CASCADE,
+	goroutine_id INTEGER NOT NULL,
+	label TEXT,
+	creation_hash TEXT,
+	runtime_ns INTEGER NOT NULL,
+	runnable_ns INTEGER NOT NULL,
+	blocked_ns INTEGER NOT NULL,
+	primary_reason TEXT NOT NULL,
+	PRIMARY KEY (analysis_id, goroutine_id)
+);
+CREATE TABLE IF NOT EXISTS goroutine_blocking (
+	analysis_id INTEGER NOT NULL,
+	goroutine_id INTEGER NOT NULL,
+	reason TEXT NOT NULL,
+	blocked_ns INTEGER NOT NULL,
+	PRIMARY KEY (analysis_id, goroutine_id, reason),
+	FOREIGN KEY (analysis_id, goroutine_id) REFERENCES goroutines(analysis_id, goroutine_id) ON DELETE CASCADE
+);`
+}
+
+// SaveSQLite inserts the analysis of trace into the SQLite database at path,
+// creating the database and its tables if needed, and returns the new
+// analysis ID
+func SaveSQLite(path, trace string, summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo) (int64, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(sqliteSchema()); err != nil {
+		return 0, fmt.Errorf("creating tables in %s: %w", path, err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	id, err := insertAnalysis(tx, trace, summary)
+	if err != nil {
+		return 0, fmt.Errorf("inserting analysis into %s: %w", path, err)
+	}
+	if err := insertGoroutines(tx, id, goroutines); err != nil {
+		return 0, fmt.Errorf("inserting goroutines into %s: %w", path, err)
+	}
+	return id, tx.Commit()
+}
+
+// insertAnalysis writes the summary row and returns its ID
+func insertAnalysis(tx *sql.Tx, trace string, summary *model.Summary) (int64, error) {
+	cols := []string{"trace", "analyzed_at", "go_version", "gomaxprocs", "goroutines", "wall_ns",
+		"runtime_ns", "runnable_ns", "blocked_ns", "verdict", "has_issues", "incomplete"}
+	var goVersion, gomaxprocs any
+	if t := summary.Trace; t != nil {
+		goVersion = t.GoVersion
+		if t.GOMAXPROCS > 0 {
+			gomaxprocs = t.GOMAXPROCS
+		}
+	}
+	values := []any{trace, time.Now().UTC().Format(time.RFC3339), goVersion, gomaxprocs,
+		summary.TotalGoroutines, int64(summary.WallTime), int64(summary.TotalRuntime),
+		int64(summary.TotalRunnable), int64(summary.TotalBlockedTime), analyzer.Verdict(summary),
+		summary.HasPerformanceIssues, summary.Incomplete}
+	for _, r := range sqliteReasons() {
+		cols = append(cols, reasonColumns[r]+"_ns", reasonColumns[r]+"_pct")
+		values = append(values, int64(summary.BlockingBreakdown[r]), summary.BlockingPercent[r])
+	}
+
+	query := fmt.Sprintf("INSERT INTO analyses (%s) VALUES (?%s)",
+		strings.Join(cols, ", "), strings.Repeat(", ?", len(cols)-1))
+	res, err := tx.Exec(query, values...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// insertGoroutines writes a row per goroutine and per goroutine and reason
+func insertGoroutines(tx *sql.Tx, id int64, goroutines map[uint64]*model.GoroutineInfo) error {
+	gStmt, err := tx.Prepare(`INSERT INTO goroutines (analysis_id, goroutine_id, label, creation_hash,
+	runtime_ns, runnable_ns, blocked_ns, primary_reason) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer gStmt.Close()
+	rStmt, err := tx.Prepare(`INSERT INTO goroutine_blocking (analysis_id, goroutine_id, reason, blocked_ns) VALUES (?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer rStmt.Close()
+
+	ids := make([]uint64, 0, len(goroutines))
+	for gid := range goroutines {
+		ids = append(ids, gid)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, gid := range ids {
+		g := goroutines[gid]
+		if _, err := gStmt.Exec(id, int64(gid), nullString(g.Label), nullString(g.CreationHash),
+			int64(g.TotalRuntime), int64(g.TotalRunnable), int64(g.TotalBlocked),
+			getPrimaryBlockingReason(g).String()); err != nil {
+			return err
+		}
+		for _, r := range model.AllBlockingReasons() {
+			if d := g.BlockingByReason[r]; d > 0 {
+				if _, err := rStmt.Exec(id, int64(gid), r.String(), int64(d)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
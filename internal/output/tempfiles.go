@@ -0,0 +1,29 @@
+package output
+
+import (
+	"os"
+	"sync"
+)
+
+// tempTraces are live captures written to disk during this process
+var tempTraces struct {
+	sync.Mutex
+	paths []string
+}
+
+// trackTempTrace registers a temporary trace for removal at exit
+func trackTempTrace(path string) {
+	tempTraces.Lock()
+	defer tempTraces.Unlock()
+	tempTraces.paths = append(tempTraces.paths, path)
+}
+
+// RemoveTempTraces deletes every temporary trace captured so far
+func RemoveTempTraces() {
+	tempTraces.Lock()
+	defer tempTraces.Unlock()
+	for _, path := range tempTraces.paths {
+		os.Remove(path)
+	}
+	tempTraces.paths = nil
+}
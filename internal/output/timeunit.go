@@ -0,0 +1,44 @@
+package output
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeUnit selects how the text formatter renders durations
+type TimeUnit string
+
+const (
+	// UnitAuto picks the unit per value (the default); the others use one
+	// fixed unit everywhere so columns line up
+	UnitAuto         TimeUnit = "auto"
+	UnitNanoseconds  TimeUnit = "ns"
+	UnitMicroseconds TimeUnit = "us"
+	UnitMilliseconds TimeUnit = "ms"
+	UnitSeconds      TimeUnit = "s"
+)
+
+// ParseTimeUnit validates a --time-unit value
+func ParseTimeUnit(s string) (TimeUnit, error) {
+	switch u := TimeUnit(s); u {
+	case UnitAuto, UnitNanoseconds, UnitMicroseconds, UnitMilliseconds, UnitSeconds:
+		return u, nil
+	}
+	return "", fmt.Errorf("unknown time unit %q (want ns, us, ms, s or auto)", s)
+}
+
+// Format renders d in the unit; the zero value behaves like UnitAuto
+func (u TimeUnit) Format(d time.Duration) string {
+	switch u {
+	case UnitNanoseconds:
+		return fmt.Sprintf("%dns", d.Nanoseconds())
+	case UnitMicroseconds:
+		return fmt.Sprintf("%.3fμs", float64(d)/float64(time.Microsecond))
+	case UnitMilliseconds:
+		return fmt.Sprintf("%.3fms", float64(d)/float64(time.Millisecond))
+	case UnitSeconds:
+		return fmt.Sprintf("%.6fs", d.Seconds())
+	default:
+		return formatDuration(d)
+	}
+}
@@ -2,12 +2,16 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/goschedviz/goschedviz/internal/analyzer"
 	"github.com/goschedviz/goschedviz/internal/model"
 )
 
@@ -30,8 +34,15 @@ type modelState int
 const (
 	stateTable modelState = iota
 	stateDetail
+	statePlayback
+	stateReasons
+	stateContributors
 )
 
+// rowWindow is how many table rows are materialized around the cursor; the
+// full sorted order is kept as pointers so huge traces stay responsive
+const rowWindow = 500
+
 type sortField int
 
 const (
@@ -48,7 +59,31 @@ type ExplorerModel struct {
 	state        modelState
 	selectedID   uint64
 	sortField    sortField
+	sortReverse  bool
 	filterReason model.BlockingReason
+	statusMsg    string
+
+	// order is the full filtered and sorted goroutine list; only the slice
+	// starting at windowStart is rendered into table rows
+	order       []*model.GoroutineInfo
+	windowStart int
+
+	playback playback
+
+	// drill is the reason overview; detailReturn is the view esc goes back
+	// to from a goroutine's details
+	drill        reasonDrill
+	detailReturn modelState
+
+	// reanalyze re-runs the analysis on the parsed trace when a toggle
+	// changes; nil when the caller didn't provide one
+	reanalyze Reanalyzer
+	toggles   AnalysisToggles
+}
+
+// yankResultMsg reports the outcome of copying a goroutine summary
+type yankResultMsg struct {
+	err error
 }
 
 func NewExplorerModel(summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo) ExplorerModel {
@@ -88,32 +123,99 @@ func (m ExplorerModel) Init() tea.Cmd { return nil }
 func (m ExplorerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
+	case yankResultMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("copy failed: %v", msg.err)
+		} else {
+			m.statusMsg = "✔ copied goroutine summary to clipboard"
+		}
+		return m, nil
+	case playbackTickMsg:
+		return m, m.playback.advance(msg)
 	case tea.KeyMsg:
+		if m.state == statePlayback {
+			return m.updatePlayback(msg)
+		}
+		if m.state == stateReasons || m.state == stateContributors {
+			return m.updateReasons(msg)
+		}
 		switch msg.String() {
+		case "y":
+			if m.state == stateDetail {
+				if g, ok := m.goroutines[m.selectedID]; ok {
+					return m, yankGoroutineSummary(g)
+				}
+				return m, nil
+			}
 		case "esc":
 			if m.state == stateDetail {
-				m.state = stateTable
+				m.state = m.detailReturn
+				m.statusMsg = ""
 				return m, nil
 			}
 			// In dashboard mode, we might want to let the parent handle Quit or Back
 			return m, nil
+		case "p":
+			if m.state == stateTable {
+				p, ok := newPlayback(m.goroutines)
+				if !ok {
+					m.statusMsg = "playback needs state intervals (run explore with --playback)"
+					return m, nil
+				}
+				m.playback = p
+				m.state = statePlayback
+				m.statusMsg = ""
+				return m, m.playback.toggle()
+			}
+		case "o":
+			if m.state == stateTable {
+				m.drill = newReasonDrill(m.summary)
+				m.state = stateReasons
+				m.statusMsg = ""
+				return m, nil
+			}
+		case "m", "i", "n":
+			if m.state == stateTable {
+				t := m.toggles
+				switch msg.String() {
+				case "m":
+					t.ExcludeMain = !t.ExcludeMain
+				case "i":
+					t.ExcludeIdle = !t.ExcludeIdle
+				case "n":
+					if t.EventsCapped {
+						m.statusMsg = "noise floor needs every event (run explore without --max-events-per-goroutine)"
+						return m, nil
+					}
+					t.NoiseFloor = t.nextNoiseFloor()
+				}
+				m.toggle(t)
+				return m, nil
+			}
 		case "s":
 			m.sortField = (m.sortField + 1) % 3
 			m.RefreshTable()
+		case "r":
+			m.sortReverse = !m.sortReverse
+			m.RefreshTable()
 		case "f":
 			m.cycleFilter()
 			m.RefreshTable()
+		case "up", "k", "down", "j", "pgup", "pgdown", "home", "g", "end", "G":
+			if m.state == stateTable {
+				m.navigate(msg.String())
+				return m, nil
+			}
 		case "enter":
 			if m.state == stateTable {
-				row := m.table.SelectedRow()
-				if row == nil {
+				g := m.cursorGoroutine()
+				if g == nil {
 					return m, nil
 				}
-				idStr := row[0]
-				var id uint64
-				fmt.Sscanf(idStr, "#%d", &id)
-				m.selectedID = id
+				m.selectedID = g.ID
+				m.detailReturn = stateTable
 				m.state = stateDetail
+				m.statusMsg = ""
 				return m, nil
 			}
 		}
@@ -134,16 +236,91 @@ func (m *ExplorerModel) cycleFilter() {
 	case model.BlockMutexLock:
 		m.filterReason = model.BlockSyscall
 	case model.BlockSyscall:
+		m.filterReason = model.BlockCgo
+	case model.BlockCgo:
 		m.filterReason = model.BlockGC
 	default:
 		m.filterReason = model.BlockNone
 	}
 }
 
+// cursorGoroutine returns the goroutine behind the selected row, taken from
+// order rather than parsed back out of the rendered ID column
+func (m *ExplorerModel) cursorGoroutine() *model.GoroutineInfo {
+	pos := m.windowStart + m.table.Cursor()
+	if pos < 0 || pos >= len(m.order) {
+		return nil
+	}
+	return m.order[pos]
+}
+
+// navigate moves the cursor across the full order, shifting the rendered
+// window when the cursor leaves it
+func (m *ExplorerModel) navigate(key string) {
+	if len(m.order) == 0 {
+		return
+	}
+	pos := m.windowStart + m.table.Cursor()
+	page := m.table.Height()
+	switch key {
+	case "up", "k":
+		pos--
+	case "down", "j":
+		pos++
+	case "pgup":
+		pos -= page
+	case "pgdown":
+		pos += page
+	case "home", "g":
+		pos = 0
+	case "end", "G":
+		pos = len(m.order) - 1
+	}
+	pos = max(0, min(pos, len(m.order)-1))
+
+	if pos < m.windowStart || pos >= m.windowStart+rowWindow {
+		m.setWindow(pos - rowWindow/2)
+	}
+	m.table.SetCursor(pos - m.windowStart)
+}
+
+// setWindow renders the rows of order starting at start
+func (m *ExplorerModel) setWindow(start int) {
+	start = max(0, min(start, len(m.order)-rowWindow))
+	end := min(start+rowWindow, len(m.order))
+	m.windowStart = start
+
+	rows := make([]table.Row, 0, end-start)
+	for _, g := range m.order[start:end] {
+		rows = append(rows, m.goroutineRow(g))
+	}
+	m.table.SetRows(rows)
+}
+
+// goroutineRow renders a single table row
+func (m *ExplorerModel) goroutineRow(g *model.GoroutineInfo) table.Row {
+	bar := ""
+	if m.summary.TotalBlockedTime > 0 {
+		pct := float64(g.TotalBlocked) / float64(m.summary.TotalBlockedTime) * 100
+		width := int(pct / 2) // scale down
+		if width > 10 {
+			width = 10
+		}
+		if width > 0 {
+			bar = " " + strings.Repeat("█", width)
+		}
+	}
+
+	return table.Row{
+		g.DisplayName(),
+		formatDuration(g.TotalBlocked) + bar,
+		formatDuration(g.TotalRuntime),
+		getPrimaryBlockingReason(g).String(),
+	}
+}
+
 // RefreshTable updates the table data based on current state
 func (m *ExplorerModel) RefreshTable() {
-	// ... logic needs to be moved here from original refreshTable
-	// Copying the logic from the original file but adapting receiver
 	var filtered []*model.GoroutineInfo
 	for _, g := range m.goroutines {
 		if m.filterReason != model.BlockNone {
@@ -155,6 +332,9 @@ func (m *ExplorerModel) RefreshTable() {
 	}
 
 	sort.Slice(filtered, func(i, j int) bool {
+		if m.sortReverse {
+			i, j = j, i
+		}
 		switch m.sortField {
 		case sortBlocked:
 			return filtered[i].TotalBlocked > filtered[j].TotalBlocked
@@ -167,49 +347,40 @@ func (m *ExplorerModel) RefreshTable() {
 		}
 	})
 
-	var rows []table.Row
-	for _, g := range filtered {
-		bar := ""
-		if m.summary.TotalBlockedTime > 0 {
-			pct := float64(g.TotalBlocked) / float64(m.summary.TotalBlockedTime) * 100
-			width := int(pct / 2) // scale down
-			if width > 10 {
-				width = 10
-			}
-			if width > 0 {
-				bar = " " + strings.Repeat("█", width)
-			}
-		}
-
-		rows = append(rows, table.Row{
-			fmt.Sprintf("#%d", g.ID),
-			formatDuration(g.TotalBlocked) + bar,
-			formatDuration(g.TotalRuntime),
-			getPrimaryBlockingReason(g).String(),
-		})
-	}
+	m.order = filtered
 
 	columns := []table.Column{
-		{Title: "ID " + m.sortIndicator(sortID), Width: 8},
+		{Title: "ID " + m.sortIndicator(sortID), Width: 20},
 		{Title: "Blocked " + m.sortIndicator(sortBlocked), Width: 20},
 		{Title: "Runtime " + m.sortIndicator(sortRuntime), Width: 12},
 		{Title: "Primary Reason", Width: 20},
 	}
 
 	m.table.SetColumns(columns)
-	m.table.SetRows(rows)
+	m.setWindow(0)
+	m.table.SetCursor(0)
 }
 
+// sortIndicator shows the active sort column and its direction; ID sorts
+// ascending by default, the duration columns descending
 func (m ExplorerModel) sortIndicator(field sortField) string {
-	if m.sortField == field {
-		return "↓"
+	if m.sortField != field {
+		return ""
+	}
+	if (field == sortID) != m.sortReverse {
+		return "↑"
 	}
-	return ""
+	return "↓"
 }
 
 func (m ExplorerModel) View() string {
-	if m.state == stateDetail {
+	switch m.state {
+	case stateDetail:
 		return m.detailView()
+	case statePlayback:
+		return m.playbackView()
+	case stateReasons, stateContributors:
+		return m.reasonsView()
 	}
 
 	// Remove the static header since Dashboard will likely provide it
@@ -229,16 +400,21 @@ func (m ExplorerModel) View() string {
 		filterStr = m.filterReason.String()
 	}
 
-	stats := fmt.Sprintf("\n Goroutines: %d | Total Blocked: %s | Filter: %s\n",
-		len(m.table.Rows()),
+	stats := fmt.Sprintf("\n Goroutines: %d | Total Blocked: %s | Filter: %s | Analysis: %s\n",
+		len(m.order),
 		formatDuration(m.summary.TotalBlockedTime),
-		filterStr)
+		filterStr,
+		m.toggles)
+	if m.summary.Incomplete {
+		stats += dangerStyle.Render(" ⚠ Trace was truncated — results may be incomplete") + "\n"
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		s,
 		stats,
 		baseStyle.Render(m.table.View()),
-		helpStyle.Render(" • ↑/↓: navigate • s: sort • f: filter • enter: inspect • esc: back"),
+		successStyle.Render(m.statusMsg),
+		helpStyle.Render(" • ↑/↓: navigate • s: sort • r: reverse • f: filter • o: reasons • enter: inspect • p: playback • esc: back\n • m: exclude main • i: exclude idle • n: noise floor"),
 	)
 }
 
@@ -251,10 +427,15 @@ func (m ExplorerModel) detailView() string {
 		Background(lipgloss.Color("#7D56F4")).
 		Padding(0, 1).
 		Bold(true).
-		Render(fmt.Sprintf(" GOROUTINE #%d DETAILS ", g.ID))
+		Render(fmt.Sprintf(" GOROUTINE %s DETAILS ", g.DisplayName()))
 
+	entry := g.Name
+	if entry == "" {
+		entry = "unknown"
+	}
 	content := fmt.Sprintf(
-		"State:     %s\nRuntime:   %s\nRunnable:  %s\nBlocked:   %s\n\nRecent Events:\n",
+		"Entry:     %s\nState:     %s\nRuntime:   %s\nRunnable:  %s\nBlocked:   %s\n\nRecent Events:\n",
+		entry,
 		g.CurrentState,
 		formatDuration(g.TotalRuntime),
 		formatDuration(g.TotalRunnable),
@@ -265,18 +446,113 @@ func (m ExplorerModel) detailView() string {
 		ev := g.BlockingEvents[i]
 		content += fmt.Sprintf(" - %s (%s)\n", ev.Reason, formatDuration(ev.Duration))
 	}
+	content += reasonDetail(g)
 
 	return lipgloss.JoinVertical(lipgloss.Left,
 		banner,
 		"\n",
 		detailStyle.Render(content),
-		helpStyle.Render(" • esc: back to list"),
+		successStyle.Render(m.statusMsg),
+		helpStyle.Render(" • y: copy summary • esc: back"),
 	)
 }
 
-// StartTUI launches the interactive dashboard (Legacy wrapper)
-func StartTUI(summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo) error {
+// maxDetailGroups caps each list in the reason-specific detail panel
+const maxDetailGroups = 5
+
+// reasonDetail renders extra context tailored to g's primary blocking reason:
+// peers for channels, lock sites and releasers for locks, call names for syscalls
+func reasonDetail(g *model.GoroutineInfo) string {
+	reason := getPrimaryBlockingReason(g)
+	var sb strings.Builder
+	switch reason {
+	case model.BlockChannelRecv, model.BlockChannelSend:
+		writeBlockGroups(&sb, "Channel peers (woke it up):", analyzer.GroupBlocks(g, reason, wakerKey))
+	case model.BlockMutexLock:
+		writeBlockGroups(&sb, "Contended locks:", analyzer.GroupBlocks(g, reason, siteKey))
+		writeBlockGroups(&sb, "Lock holders (released it):", analyzer.GroupBlocks(g, reason, wakerKey))
+	case model.BlockSync:
+		writeBlockGroups(&sb, "Waited on:", analyzer.GroupBlocks(g, reason, siteKey))
+		writeBlockGroups(&sb, "Released by:", analyzer.GroupBlocks(g, reason, wakerKey))
+	case model.BlockSyscall:
+		writeBlockGroups(&sb, "Syscalls:", analyzer.GroupBlocks(g, reason, func(ev model.BlockingEvent) string {
+			return ev.Op()
+		}))
+	case model.BlockCgo:
+		writeBlockGroups(&sb, "C calls from:", analyzer.GroupBlocks(g, reason, siteKey))
+	case model.BlockSelect:
+		writeBlockGroups(&sb, "Select ended on:", analyzer.GroupBlocks(g, reason, func(ev model.BlockingEvent) string {
+			return ev.SelectCase.String()
+		}))
+	}
+	return sb.String()
+}
+
+// wakerKey labels a block by the goroutine that ended it
+func wakerKey(ev model.BlockingEvent) string {
+	if ev.WakerID == 0 {
+		return "runtime"
+	}
+	return fmt.Sprintf("#%d", ev.WakerID)
+}
+
+// siteKey labels a block by the blocking operation and the code that issued it
+func siteKey(ev model.BlockingEvent) string {
+	if ev.Site() == "" {
+		return ev.Op()
+	}
+	return ev.Op() + " in " + ev.Site()
+}
+
+// writeBlockGroups writes a titled list of the largest groups, if any
+func writeBlockGroups(sb *strings.Builder, title string, groups []analyzer.BlockGroup) {
+	if len(groups) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n%s\n", title)
+	for i := 0; i < len(groups) && i < maxDetailGroups; i++ {
+		fmt.Fprintf(sb, " - %s: %dx, %s\n", groups[i].Key, groups[i].Count, formatDuration(groups[i].Total))
+	}
+	if rest := len(groups) - maxDetailGroups; rest > 0 {
+		fmt.Fprintf(sb, "   … and %d more\n", rest)
+	}
+}
+
+// goroutineSummaryText renders a plain-text goroutine summary for pasting into tickets
+func goroutineSummaryText(g *model.GoroutineInfo) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Goroutine %s\n", g.DisplayName())
+	fmt.Fprintf(&sb, "State:          %s\n", g.CurrentState)
+	fmt.Fprintf(&sb, "Runtime:        %s\n", formatDuration(g.TotalRuntime))
+	fmt.Fprintf(&sb, "Runnable:       %s\n", formatDuration(g.TotalRunnable))
+	fmt.Fprintf(&sb, "Blocked:        %s\n", formatDuration(g.TotalBlocked))
+	fmt.Fprintf(&sb, "Primary reason: %s\n", getPrimaryBlockingReason(g))
+	fmt.Fprintf(&sb, "Blocking events: %d\n", len(g.BlockingEvents)+g.DroppedEvents)
+	for i := 0; i < len(g.BlockingEvents) && i < 10; i++ {
+		ev := g.BlockingEvents[i]
+		fmt.Fprintf(&sb, "  - %s (%s) @ %s\n", ev.Reason, formatDuration(ev.Duration), formatDuration(ev.StartTime))
+	}
+	return sb.String()
+}
+
+// yankGoroutineSummary copies the summary to the system clipboard, falling back
+// to an OSC 52 escape sequence for remote terminals without a clipboard tool
+func yankGoroutineSummary(g *model.GoroutineInfo) tea.Cmd {
+	text := goroutineSummaryText(g)
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err == nil {
+			return yankResultMsg{}
+		}
+		_, err := osc52.New(text).WriteTo(os.Stderr)
+		return yankResultMsg{err: err}
+	}
+}
+
+// StartTUI launches the interactive dashboard (Legacy wrapper); with a
+// non-nil reanalyze the analysis toggles re-run the analyzer
+func StartTUI(summary *model.Summary, goroutines map[uint64]*model.GoroutineInfo, reanalyze Reanalyzer, toggles AnalysisToggles) error {
 	m := NewExplorerModel(summary, goroutines)
+	m.SetReanalyzer(reanalyze, toggles)
 	// We need to wrap it to handle Quit properly if run standalone
 	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
 		return err
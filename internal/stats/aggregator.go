@@ -19,8 +19,9 @@ func NewAggregator(goroutines map[uint64]*model.GoroutineInfo) *Aggregator {
 	}
 }
 
-// ComputeSummary generates aggregate metrics
-func (a *Aggregator) ComputeSummary() *model.Summary {
+// ComputeSummary generates aggregate metrics, listing the topN most blocked
+// goroutines (all of them when topN is 0, as with --top-n)
+func (a *Aggregator) ComputeSummary(topN int) *model.Summary {
 	summary := &model.Summary{
 		TotalGoroutines:   len(a.goroutines),
 		PeakGoroutines:    len(a.goroutines),
@@ -47,12 +48,13 @@ func (a *Aggregator) ComputeSummary() *model.Summary {
 		}
 	}
 
-	summary.TopBlocked = a.getTopBlocked(10)
+	summary.TopBlocked = a.getTopBlocked(topN)
 
 	return summary
 }
 
-// getTopBlocked returns top N goroutines by blocked time
+// getTopBlocked returns top N goroutines by blocked time, or all of them
+// when n is not positive
 func (a *Aggregator) getTopBlocked(n int) []*model.GoroutineInfo {
 	type item struct {
 		g       *model.GoroutineInfo
@@ -70,7 +72,7 @@ func (a *Aggregator) getTopBlocked(n int) []*model.GoroutineInfo {
 		return items[i].blocked > items[j].blocked
 	})
 
-	if len(items) < n {
+	if n <= 0 || len(items) < n {
 		n = len(items)
 	}
 
@@ -1,9 +1,14 @@
 package traceparser
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -12,38 +17,216 @@ import (
 	"golang.org/x/exp/trace"
 )
 
+// ErrPartialTrace is returned (wrapped) by Parse when the trace stream ends
+// mid-event; the accompanying ParseResult holds everything parsed before that
+var ErrPartialTrace = errors.New("trace stream ended unexpectedly, results may be incomplete")
+
 // ParseResult contains the parsed trace data
 type ParseResult struct {
 	Goroutines map[uint64]*model.GoroutineInfo
 	Errors     []error
+
+	// Partial is set when the stream was truncated before a clean EOF
+	Partial bool
+
+	// EventCount is the total number of events read from the trace
+	EventCount int64
+
+	// SkippedEvents counts events that could not be processed
+	SkippedEvents int
+
+	// Clock maps trace timestamps to wall-clock time; nil when the trace
+	// carries no clock snapshot (traces before Go 1.25)
+	Clock *model.ClockReference
+
+	// GOMAXPROCS as reported by the trace's metric events (0 if unknown)
+	GOMAXPROCS int
+
+	// GoVersion is the trace format version from the header, e.g. "go1.23"
+	GoVersion string
+
+	// Precision records the trace clock's resolution and how many event
+	// timestamps the reader had to adjust across batch boundaries
+	Precision model.TimestampPrecision
+
+	// InvalidTransitions counts impossible state transitions by kind (only
+	// with WithValidation)
+	InvalidTransitions map[string]int
+
+	// Procs is each P's running and idle time and how many goroutines were
+	// scheduled on it
+	Procs map[uint64]*model.ProcInfo
+
+	// PeakRunnable is the most goroutines that were runnable at once
+	PeakRunnable int
+
+	// BusyProcs is a step function of how many Ps were running, sampled at
+	// every change
+	BusyProcs []model.ProcSample
+
+	// UnmappedReasons counts the trace wait reasons that matched neither an
+	// override nor a built-in rule and so were classified as BlockNone
+	UnmappedReasons map[string]int
+
+	// GC counts the collection cycles and GC stop-the-world pauses; the
+	// per-second rate is left to the analyzer, which knows the trace span
+	GC model.GCActivity
+
+	// traceStart is the timestamp of the first event; set by the reader
+	// before any event is handed to a worker
+	traceStart trace.Time
 }
 
 // Parser handles concurrent parsing of trace files
 type Parser struct {
-	numWorkers int
+	numWorkers     int
+	stateIntervals bool
+	maxEvents      int
+	reasonSeq      bool
+	progress       func(events int64)
+	overrides      []ReasonOverride
+
+	// validate enables transition checks; lastState is each goroutine's
+	// last trace state, guarded by the result mutex
+	validate  bool
+	lastState map[uint64]trace.GoState
+
+	// stacks enables blocking stack capture; pendingStacks holds each open
+	// block's stack until it completes, guarded by the result mutex
+	stacks        bool
+	pendingStacks map[uint64]trace.Stack
+
+	// sites caches the site ID of each stack seen in the current
+	// generation, guarded by the result mutex
+	sites map[trace.Stack]model.SiteID
+}
+
+// Option configures a Parser
+type Option func(*Parser)
+
+// WithStateIntervals records every per-goroutine state interval, which the
+// time-resolved exports need; off by default to keep memory bounded
+func WithStateIntervals() Option {
+	return func(p *Parser) {
+		p.stateIntervals = true
+	}
+}
+
+// WithWorkers sets the number of parse workers; n <= 0 keeps the default of
+// one worker per CPU
+func WithWorkers(n int) Option {
+	return func(p *Parser) {
+		if n > 0 {
+			p.numWorkers = n
+		}
+	}
+}
+
+// WithMaxEventsPerGoroutine keeps at most n blocking events per goroutine,
+// preferring the longest; per-reason totals and event counts still include
+// every event. Analyses that re-derive totals from the events themselves, such
+// as a time window or a noise floor, can't be exact under a cap. n <= 0 keeps
+// them all.
+func WithMaxEventsPerGoroutine(n int) Option {
+	return func(p *Parser) {
+		p.maxEvents = n
+	}
+}
+
+// WithValidation checks every goroutine transition against the previous one
+// and counts impossible ones in ParseResult.InvalidTransitions; a nonzero
+// count means a malformed trace or a wrong assumption in the parser
+func WithValidation() Option {
+	return func(p *Parser) {
+		p.validate = true
+		p.lastState = make(map[uint64]trace.GoState)
+	}
+}
+
+// WithStacks keeps the stack of every completed blocking event. Stacks are
+// only symbolized once a block completes, but it still costs time and memory,
+// so it is off by default.
+func WithStacks() Option {
+	return func(p *Parser) {
+		p.stacks = true
+		p.pendingStacks = make(map[uint64]trace.Stack)
+	}
+}
+
+// WithReasonSequence records the ordered blocking reasons of every goroutine,
+// which reason-cycle detection needs; unlike BlockingEvents it is never capped
+func WithReasonSequence() Option {
+	return func(p *Parser) {
+		p.reasonSeq = true
+	}
+}
+
+// ReasonOverride classifies every wait reason containing Substring (case
+// insensitive) as Reason, ahead of the built-in matching
+type ReasonOverride struct {
+	Substring string
+	Reason    model.BlockingReason
+}
+
+// WithReasonOverrides adds user-supplied reason mappings, tried in order
+// before the built-in ones; this lets newer or custom runtimes' wait reasons
+// be classified without a code change
+func WithReasonOverrides(overrides []ReasonOverride) Option {
+	return func(p *Parser) {
+		p.overrides = overrides
+	}
+}
+
+// progressEvery is how many events the reader reads between progress callbacks
+const progressEvery = 1 << 14
+
+// WithProgress calls fn from the reading goroutine with the number of events
+// read so far, every few thousand events
+func WithProgress(fn func(events int64)) Option {
+	return func(p *Parser) {
+		p.progress = fn
+	}
 }
 
 // NewParser creates a new trace parser with specified worker count
-func NewParser() *Parser {
-	return &Parser{
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
 		numWorkers: runtime.NumCPU(),
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
 // Parse reads and parses a trace file concurrently using sharding to ensure consistency
 func (p *Parser) Parse(r io.Reader) (*ParseResult, error) {
-	reader, err := trace.NewReader(r)
+	br := bufio.NewReader(r)
+	minor, err := detectTraceVersion(br)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := trace.NewReader(br)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create trace reader: %w", err)
+		return nil, fmt.Errorf("failed to create trace reader for trace format 1.%d: %w", minor, err)
 	}
 
 	result := &ParseResult{
 		Goroutines: make(map[uint64]*model.GoroutineInfo),
+		Procs:      make(map[uint64]*model.ProcInfo),
 		Errors:     make([]error, 0),
+		GoVersion:  fmt.Sprintf("go1.%d", minor),
 	}
 
 	var mu sync.Mutex
 	var wg sync.WaitGroup
+	var readErr error
+	p.sites = make(map[trace.Stack]model.SiteID)
+	var stwStart, lastTime trace.Time
+	var resolution int64
+	procRunning := make(map[trace.ProcID]bool)
+	runnable := make(map[trace.GoID]bool)
 
 	// Create sharded channels for workers
 	shards := make([]chan trace.Event, p.numWorkers)
@@ -62,21 +245,90 @@ func (p *Parser) Parse(r io.Reader) (*ParseResult, error) {
 			ev, err := reader.ReadEvent()
 			if err != nil {
 				if err != io.EOF {
+					readErr = err
 					mu.Lock()
 					result.Errors = append(result.Errors, fmt.Errorf("read event error: %w", err))
 					mu.Unlock()
 				}
 				break
 			}
+			if result.EventCount == 0 {
+				result.traceStart = ev.Time()
+			}
+			result.EventCount++
+			if p.progress != nil && result.EventCount%progressEvery == 0 {
+				p.progress(result.EventCount)
+			}
 
-			// Shard events by Goroutine ID to ensure ordering per goroutine
-			if ev.Kind() == trace.EventStateTransition {
+			// An event exactly 1ns after the previous one was moved there by
+			// the reader; everything else sits on the clock's tick grid
+			if result.EventCount > 1 && ev.Time()-lastTime == 1 {
+				result.Precision.Adjusted++
+			} else if resolution != 1 {
+				resolution = gcd(resolution, int64(ev.Time()))
+			}
+			lastTime = ev.Time()
+
+			// The first sync event with a clock snapshot anchors wall-clock time
+			if ev.Kind() == trace.EventSync {
+				result.Precision.Generations++
+				// Stacks are per generation, so cached sites can't match again
+				mu.Lock()
+				clear(p.sites)
+				mu.Unlock()
+				if snap := ev.Sync().ClockSnapshot; snap != nil && result.Clock == nil {
+					result.Clock = &model.ClockReference{
+						Trace: time.Duration(snap.Trace),
+						Wall:  snap.Wall,
+					}
+				}
+				continue
+			}
+
+			// GOMAXPROCS is emitted as a runtime metric sample
+			if ev.Kind() == trace.EventMetric {
+				m := ev.Metric()
+				if m.Name == "/sched/gomaxprocs:threads" && m.Value.Kind() == trace.ValueUint64 {
+					result.GOMAXPROCS = int(m.Value.Uint64())
+				}
+				continue
+			}
+
+			// GC cycles and stop-the-world pauses are global ranges, so the
+			// reader tracks them itself
+			if ev.Kind() == trace.EventRangeBegin || ev.Kind() == trace.EventRangeEnd {
+				trackGC(ev, &result.GC, &stwStart)
+				continue
+			}
+
+			// Shard events by Goroutine ID to ensure ordering per goroutine;
+			// P transitions are few and tracked here, so Procs needs no lock
+			switch ev.Kind() {
+			case trace.EventStateTransition:
 				st := ev.StateTransition()
+				if st.Resource.Kind == trace.ResourceProc {
+					trackBusyProcs(st, ev.Time(), procRunning, &result.BusyProcs)
+					trackProc(result.Procs, st, ev.Time(), result.traceStart)
+					continue
+				}
 				if st.Resource.Kind == trace.ResourceGoroutine {
+					countSchedule(result.Procs, ev, st)
+					trackRunnable(st, runnable, &result.PeakRunnable)
 					gid := uint64(st.Resource.Goroutine())
 					shards[gid%uint64(p.numWorkers)] <- ev
 					continue
 				}
+			case trace.EventLabel:
+				if l := ev.Label(); l.Resource.Kind == trace.ResourceGoroutine {
+					gid := uint64(l.Resource.Goroutine())
+					shards[gid%uint64(p.numWorkers)] <- ev
+					continue
+				}
+			case trace.EventRegionBegin:
+				if g := ev.Goroutine(); g != trace.NoGoroutine {
+					shards[uint64(g)%uint64(p.numWorkers)] <- ev
+					continue
+				}
 			}
 			// For non-goroutine events, or other kind of events, discard for now
 			// unless needed for global context
@@ -85,78 +337,530 @@ func (p *Parser) Parse(r io.Reader) (*ParseResult, error) {
 
 	// Wait for all workers to complete
 	wg.Wait()
+	result.Precision.Resolution = time.Duration(resolution)
+	for _, proc := range result.Procs {
+		accountProc(proc, time.Duration(lastTime))
+	}
+	if p.stateIntervals {
+		closeIntervals(result.Goroutines, time.Duration(lastTime))
+	}
+
+	if p.maxEvents > 0 {
+		for _, g := range result.Goroutines {
+			keepLongestEvents(g, p.maxEvents)
+		}
+	}
+
+	// Keep what was parsed so callers can still analyze a truncated capture
+	if readErr != nil {
+		result.Partial = true
+		return result, fmt.Errorf("%w: %v", ErrPartialTrace, readErr)
+	}
 
 	return result, nil
 }
 
+// trackBusyProcs updates which Ps are running and appends a sample when the
+// count changes; status restatements at generation boundaries change nothing
+func trackBusyProcs(st trace.StateTransition, ts trace.Time, running map[trace.ProcID]bool, samples *[]model.ProcSample) {
+	_, to := st.Proc()
+	id := st.Resource.Proc()
+	if running[id] == (to == trace.ProcRunning) {
+		return
+	}
+	if to == trace.ProcRunning {
+		running[id] = true
+	} else {
+		delete(running, id)
+	}
+	*samples = append(*samples, model.ProcSample{At: time.Duration(ts), Busy: len(running)})
+}
+
+// trackProc accounts a P's time in the state it leaves. A P's first event is
+// its status, which holds since the start of the trace.
+func trackProc(procs map[uint64]*model.ProcInfo, st trace.StateTransition, ts, start trace.Time) {
+	from, to := st.Proc()
+	id := uint64(st.Resource.Proc())
+	proc, seen := procs[id]
+	if !seen {
+		proc = &model.ProcInfo{ID: id, LastStateChange: time.Duration(start)}
+		procs[id] = proc
+		if from == trace.ProcUndetermined {
+			from = to
+		}
+		proc.Running, proc.Idle = from == trace.ProcRunning, from == trace.ProcIdle
+	}
+	accountProc(proc, time.Duration(ts))
+	proc.Running, proc.Idle = to == trace.ProcRunning, to == trace.ProcIdle
+}
+
+// accountProc adds the time since the P's last state change to that state
+func accountProc(proc *model.ProcInfo, ts time.Duration) {
+	elapsed := ts - proc.LastStateChange
+	switch {
+	case proc.Running:
+		proc.RunningTime += elapsed
+	case proc.Idle:
+		proc.IdleTime += elapsed
+	}
+	proc.LastStateChange = ts
+}
+
+// countSchedule counts a goroutine being switched onto the P it runs on;
+// status events and restatements of a running goroutine aren't schedules
+func countSchedule(procs map[uint64]*model.ProcInfo, ev trace.Event, st trace.StateTransition) {
+	from, to := st.Goroutine()
+	if to != trace.GoRunning || from == trace.GoRunning || from == trace.GoUndetermined || ev.Proc() == trace.NoProc {
+		return
+	}
+	id := uint64(ev.Proc())
+	proc, ok := procs[id]
+	if !ok {
+		proc = &model.ProcInfo{ID: id, LastStateChange: time.Duration(ev.Time())}
+		procs[id] = proc
+	}
+	proc.Schedules++
+}
+
+// trackRunnable updates which goroutines are runnable and raises peak when
+// more of them are than ever before
+func trackRunnable(st trace.StateTransition, runnable map[trace.GoID]bool, peak *int) {
+	_, to := st.Goroutine()
+	id := st.Resource.Goroutine()
+	if to != trace.GoRunnable {
+		delete(runnable, id)
+		return
+	}
+	runnable[id] = true
+	*peak = max(*peak, len(runnable))
+}
+
+// trackGC counts a cycle at each start of the concurrent mark phase and
+// measures stop-the-world pauses the runtime attributes to the GC
+func trackGC(ev trace.Event, gc *model.GCActivity, stwStart *trace.Time) {
+	name := ev.Range().Name
+	switch {
+	case name == "GC concurrent mark phase":
+		if ev.Kind() == trace.EventRangeBegin {
+			gc.Cycles++
+		}
+	case strings.HasPrefix(name, "stop-the-world (GC "):
+		if ev.Kind() == trace.EventRangeBegin {
+			*stwStart = ev.Time()
+			return
+		}
+		if *stwStart == 0 {
+			return
+		}
+		pause := ev.Time().Sub(*stwStart)
+		*stwStart = 0
+		gc.Pauses++
+		gc.TotalPause += pause
+		gc.MaxPause = max(gc.MaxPause, pause)
+	}
+}
+
+// gcd returns the greatest common divisor of a and b
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
 // worker processes events from its dedicated shard
 func (p *Parser) worker(events <-chan trace.Event, result *ParseResult, mu *sync.Mutex, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for ev := range events {
-		p.processEvent(ev, result, mu)
+		p.safeProcessEvent(ev, result, mu)
 	}
 }
 
+// safeProcessEvent processes an event, recovering from panics caused by
+// malformed or unexpected event shapes so one bad event can't sink the parse
+func (p *Parser) safeProcessEvent(ev trace.Event, result *ParseResult, mu *sync.Mutex) {
+	defer func() {
+		if r := recover(); r != nil {
+			mu.Lock()
+			result.Errors = append(result.Errors, fmt.Errorf("recovered from panic processing event %s: %v", ev.String(), r))
+			result.SkippedEvents++
+			mu.Unlock()
+		}
+	}()
+	p.processEvent(ev, result, mu)
+}
+
 // processEvent handles a single trace event
 func (p *Parser) processEvent(ev trace.Event, result *ParseResult, mu *sync.Mutex) {
+	switch ev.Kind() {
+	case trace.EventLabel:
+		l := ev.Label()
+		g := lookupGoroutine(uint64(l.Resource.Goroutine()), ev.Time(), result, mu)
+		g.Label = l.Label
+		return
+	case trace.EventRegionBegin:
+		// A goroutine without a trace label is named after its first region
+		g := lookupGoroutine(uint64(ev.Goroutine()), ev.Time(), result, mu)
+		if g.Label == "" {
+			g.Label = ev.Region().Type
+		}
+		return
+	}
+
 	if ev.Kind() == trace.EventStateTransition {
 		st := ev.StateTransition()
-		p.handleStateTransition(st, ev.Time(), result, mu)
+		// The goroutine emitting the event is the one causing the
+		// transition, e.g. the sender that wakes a blocked receiver
+		var actor uint64
+		if g := ev.Goroutine(); g != trace.NoGoroutine {
+			actor = uint64(g)
+		}
+		p.handleStateTransition(st, ev.Time(), actor, ev.Stack(), ev.Thread(), result, mu)
 	}
 }
 
-// handleStateTransition processes goroutine state changes
-func (p *Parser) handleStateTransition(st trace.StateTransition, timestamp trace.Time, result *ParseResult, mu *sync.Mutex) {
-	resource := st.Resource
-	gid := uint64(resource.Goroutine())
+// trackThread records the M a goroutine starts running on; a status event or
+// a restatement at a generation boundary only reveals where it was already
+// running, so it isn't counted as a run
+func trackThread(g *model.GoroutineInfo, thread uint64, restated bool) {
+	if !restated {
+		g.Schedules++
+		if g.Thread != 0 && g.Thread != thread {
+			g.Migrations++
+		}
+	}
+	g.Thread = thread
+}
 
+// lookupGoroutine returns the goroutine's record, creating it on first sight
+func lookupGoroutine(gid uint64, timestamp trace.Time, result *ParseResult, mu *sync.Mutex) *model.GoroutineInfo {
 	mu.Lock()
+	defer mu.Unlock()
 	g, exists := result.Goroutines[gid]
 	if !exists {
 		g = model.NewGoroutineInfo(gid, time.Duration(timestamp))
 		result.Goroutines[gid] = g
 	}
-	mu.Unlock()
+	return g
+}
+
+// handleStateTransition processes goroutine state changes
+func (p *Parser) handleStateTransition(st trace.StateTransition, timestamp trace.Time, actor uint64, actorStack trace.Stack, thread trace.ThreadID, result *ParseResult, mu *sync.Mutex) {
+	resource := st.Resource
+	gid := uint64(resource.Goroutine())
+	g := lookupGoroutine(gid, timestamp, result, mu)
 
 	// Determine blocking reason
-	reason := determineBlockingReason(st)
+	reason := p.blockingReason(st, result, mu)
 	// Map trace states to our model states
-	_, to := st.Goroutine()
+	from, to := st.Goroutine()
 	toState := mapTraceState(to)
 
 	ts := time.Duration(timestamp)
+
+	if p.validate {
+		p.validateTransition(g, from, to, reason, result, mu)
+	}
+
+	// Each run starts on the thread emitting the event; resuming on another
+	// M than last time is a migration
+	if toState == model.StateRunning && thread != trace.NoThread {
+		trackThread(g, uint64(thread), from == trace.GoUndetermined || from == to)
+	}
+
+	// A goroutine that predates the trace is named after the outermost frame
+	// of the first stack it shows; a running one's status has none
+	if g.Name == "" && (g.Preexisting || from == trace.GoUndetermined) {
+		g.Name = entryFunc(st.Stack)
+	}
+
+	// An undetermined origin means this is the goroutine's status event: it
+	// already existed when tracing started, and the status is only written
+	// when the runtime first touches it, so it has been in this state since
+	// the start of the trace
+	if from == trace.GoUndetermined && to != trace.GoNotExist {
+		p.seedInitialState(g, time.Duration(result.traceStart), st, reason, mu)
+		return
+	}
+
+	if from == trace.GoNotExist && g.CreationHash == "" {
+		g.CreationHash = creationHash(actorStack, st.Stack)
+		g.Name = entryFunc(st.Stack)
+		g.CreationStack = stackLines(actorStack)
+	}
+
+	// A blocked→blocked transition either restates the current wait (e.g. a
+	// status event at a generation boundary, which carries no reason) or
+	// updates the wait reason. Only the latter splits the block: the prior
+	// pending block is finalized below and a new one opened with the new reason.
+	if g.CurrentState == model.StateBlocked && toState == model.StateBlocked && g.PendingBlock != nil {
+		if reason == model.BlockNone || reason == g.PendingBlock.Reason {
+			return
+		}
+	}
+
 	duration := ts - g.LastStateChange
 
+	if p.stateIntervals && duration > 0 {
+		g.Intervals = append(g.Intervals, model.StateInterval{
+			Start: g.LastStateChange,
+			End:   ts,
+			State: g.CurrentState,
+		})
+	}
+
 	// Update time spent in previous state
 	switch g.CurrentState {
 	case model.StateRunning:
 		g.TotalRuntime += duration
 	case model.StateRunnable:
 		g.TotalRunnable += duration
+		if duration >= model.LongRunnableWait {
+			g.LongRunnable = append(g.LongRunnable, model.StateInterval{Start: g.LastStateChange, End: ts, State: model.StateRunnable})
+		}
 	case model.StateBlocked:
 		// If we were blocked, we complete the current pending block
 		if g.PendingBlock != nil {
 			event := *g.PendingBlock
 			event.EndTime = ts
 			event.Duration = ts - event.StartTime
+			if actor != gid {
+				event.WakerID = actor
+			}
+			if event.Reason == model.BlockSelect {
+				event.SelectCase = classifySelectWake(actor, actorStack)
+			}
+			if p.stacks {
+				event.Stack = p.takeStack(gid, mu)
+			}
 			g.AddBlockingEvent(event)
 			g.PendingBlock = nil
+			// Trimming only once the cap is doubled keeps the cost amortized
+			if p.maxEvents > 0 && len(g.BlockingEvents) >= 2*p.maxEvents {
+				keepLongestEvents(g, p.maxEvents)
+			}
 		}
 	}
 
+	if to == trace.GoNotExist {
+		g.TerminatedAt = ts
+	}
+
 	// Update current state
 	g.CurrentState = toState
 	g.LastStateChange = ts
 
 	// Start a new blocking record if entering blocked state
 	if toState == model.StateBlocked {
+		reason = classifyFinalizer(g, st.Stack, reason)
+		if p.reasonSeq {
+			g.ReasonSequence = append(g.ReasonSequence, reason)
+		}
 		g.PendingBlock = &model.BlockingEvent{
 			StartTime: ts,
 			Reason:    reason,
-			// Stack: st.Stack.String(), // Optimized: avoid expensive string conversions
+			WaitGroup: (reason == model.BlockSync || reason == model.BlockNone) && isWaitGroupWait(st),
+			Frames:    p.blockSite(st.Stack, mu),
+		}
+		if p.stacks {
+			p.holdStack(gid, st.Stack, mu)
+		}
+	}
+}
+
+// holdStack keeps the stack of gid's open block, unsymbolized until the
+// block completes
+func (p *Parser) holdStack(gid uint64, stack trace.Stack, mu *sync.Mutex) {
+	mu.Lock()
+	defer mu.Unlock()
+	p.pendingStacks[gid] = stack
+}
+
+// takeStack symbolizes and releases the stack of gid's open block
+func (p *Parser) takeStack(gid uint64, mu *sync.Mutex) *model.EventStack {
+	mu.Lock()
+	stack, ok := p.pendingStacks[gid]
+	delete(p.pendingStacks, gid)
+	mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return &model.EventStack{TopFrame: topFrame(stack), Frames: stackLines(stack)}
+}
+
+// topFrame renders the first frame of stack outside the runtime and the
+// standard blocking packages as "function file:line", with the file's base name
+func topFrame(stack trace.Stack) string {
+	for f := range stack.Frames() {
+		if !isLibraryFrame(f.Func) {
+			return fmt.Sprintf("%s %s:%d", f.Func, filepath.Base(f.File), f.Line)
+		}
+	}
+	return ""
+}
+
+// creationHash hashes the function names of the creating goroutine's stack
+// at the go statement and of the new goroutine's start stack. Line numbers
+// are left out so the hash survives unrelated edits to the same functions.
+func creationHash(creator, start trace.Stack) string {
+	h := fnv.New64a()
+	for _, stack := range []trace.Stack{creator, start} {
+		for f := range stack.Frames() {
+			h.Write([]byte(f.Func))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{1})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// entryFunc returns the outermost frame of stack: a new goroutine's start
+// stack is just its entry function, and any other stack ends in it
+func entryFunc(stack trace.Stack) string {
+	var fn string
+	for f := range stack.Frames() {
+		fn = f.Func
+	}
+	return fn
+}
+
+// stackLines renders each frame of stack as "function file:line"
+func stackLines(stack trace.Stack) []string {
+	var lines []string
+	for f := range stack.Frames() {
+		lines = append(lines, fmt.Sprintf("%s %s:%d", f.Func, f.File, f.Line))
+	}
+	return lines
+}
+
+// validateTransition records a transition that can't follow the goroutine's
+// previous one
+func (p *Parser) validateTransition(g *model.GoroutineInfo, from, to trace.GoState, reason model.BlockingReason, result *ParseResult, mu *sync.Mutex) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	prev, seen := p.lastState[g.ID]
+	p.lastState[g.ID] = to
+
+	var kind string
+	switch {
+	case from == trace.GoUndetermined:
+		// A status event states the state without a predecessor; later
+		// generations restate it as a transition to itself, handled below
+		return
+	case seen && prev == trace.GoNotExist:
+		kind = "transition after exit"
+	case seen && prev != from:
+		kind = "origin disagrees with previous state"
+	case reason != model.BlockNone && mapTraceState(to) != model.StateBlocked:
+		kind = fmt.Sprintf("block reason on %s", to)
+	case (from == trace.GoWaiting || from == trace.GoSyscall) && g.CurrentState == model.StateBlocked && g.PendingBlock == nil:
+		kind = "unblock without an open block"
+	default:
+		return
+	}
+
+	if result.InvalidTransitions == nil {
+		result.InvalidTransitions = make(map[string]int)
+	}
+	result.InvalidTransitions[kind]++
+}
+
+// seedInitialState records a goroutine that predates the trace as being in
+// its status's state since start, opening its block there if it was already blocked
+func (p *Parser) seedInitialState(g *model.GoroutineInfo, start time.Duration, st trace.StateTransition, reason model.BlockingReason, mu *sync.Mutex) {
+	_, to := st.Goroutine()
+	g.CreatedAt = start
+	g.Preexisting = true
+	g.CurrentState = mapTraceState(to)
+	g.LastStateChange = start
+	if g.CurrentState != model.StateBlocked {
+		return
+	}
+
+	// Status events carry no wait reason, so a goroutine already waiting
+	// (the norm in a flight-recorder snapshot) is classified by its stack
+	if reason == model.BlockNone {
+		reason = stackBlockingReason(st.Stack)
+	}
+	reason = classifyFinalizer(g, st.Stack, reason)
+	if p.reasonSeq {
+		g.ReasonSequence = append(g.ReasonSequence, reason)
+	}
+	g.PendingBlock = &model.BlockingEvent{
+		StartTime: start,
+		Reason:    reason,
+		WaitGroup: (reason == model.BlockSync || reason == model.BlockNone) && isWaitGroupWait(st),
+		Frames:    p.blockSite(st.Stack, mu),
+	}
+	if p.stacks {
+		p.holdStack(g.ID, st.Stack, mu)
+	}
+}
+
+// closeIntervals ends the state each live goroutine was still in at end, the
+// last event of the trace, so its final segment shows on the timeline
+func closeIntervals(goroutines map[uint64]*model.GoroutineInfo, end time.Duration) {
+	for _, g := range goroutines {
+		if g.TerminatedAt > 0 || end <= g.LastStateChange {
+			continue
+		}
+		g.Intervals = append(g.Intervals, model.StateInterval{
+			Start: g.LastStateChange,
+			End:   end,
+			State: g.CurrentState,
+		})
+	}
+}
+
+// keepLongestEvents drops all but the n longest blocking events of g, keeping
+// the survivors in chronological order
+func keepLongestEvents(g *model.GoroutineInfo, n int) {
+	events := g.BlockingEvents
+	if len(events) <= n {
+		return
+	}
+
+	durations := make([]time.Duration, len(events))
+	for i, ev := range events {
+		durations[i] = ev.Duration
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i] > durations[j]
+	})
+	threshold := durations[n-1]
+
+	// Events tied with the threshold fill whatever room the longer ones leave
+	ties := n
+	for _, d := range durations[:n] {
+		if d > threshold {
+			ties--
 		}
 	}
+
+	if g.DroppedByReason == nil {
+		g.DroppedByReason = make(map[model.BlockingReason]int)
+	}
+	kept := events[:0]
+	for _, ev := range events {
+		if ev.Duration > threshold || (ev.Duration == threshold && ties > 0) {
+			if ev.Duration == threshold {
+				ties--
+			}
+			kept = append(kept, ev)
+			continue
+		}
+		g.DroppedByReason[ev.Reason]++
+		if ev.Reason == model.BlockSelect {
+			if g.DroppedSelects == nil {
+				g.DroppedSelects = make(map[model.SelectCase]int)
+			}
+			g.DroppedSelects[ev.SelectCase]++
+		}
+	}
+	g.DroppedEvents += len(events) - len(kept)
+	g.BlockingEvents = kept
 }
 
 // mapTraceState converts trace.GoState to model.GoroutineState
@@ -168,24 +872,71 @@ func mapTraceState(s trace.GoState) model.GoroutineState {
 		return model.StateRunnable
 	case trace.GoWaiting:
 		return model.StateBlocked
+	case trace.GoNotExist:
+		return model.StateExited
 	default:
 		return model.StateBlocked
 	}
 }
 
+// blockingReason classifies a transition with the overrides, then the
+// built-in rules, counting wait reasons that neither recognizes
+func (p *Parser) blockingReason(st trace.StateTransition, result *ParseResult, mu *sync.Mutex) model.BlockingReason {
+	_, to := st.Goroutine()
+	if to == trace.GoWaiting && st.Reason != "" {
+		r := strings.ToLower(st.Reason)
+		for _, o := range p.overrides {
+			if strings.Contains(r, strings.ToLower(o.Substring)) {
+				return o.Reason
+			}
+		}
+	}
+
+	reason := determineBlockingReason(st)
+	if reason == model.BlockNone && to == trace.GoWaiting && st.Reason != "" {
+		mu.Lock()
+		if result.UnmappedReasons == nil {
+			result.UnmappedReasons = make(map[string]int)
+		}
+		result.UnmappedReasons[st.Reason]++
+		mu.Unlock()
+	}
+	return reason
+}
+
 // determineBlockingReason analyzes state transition to determine blocking cause
 func determineBlockingReason(st trace.StateTransition) model.BlockingReason {
-	reason := st.Reason
+	// Syscalls are a distinct goroutine state and carry no reason string;
+	// cgo calls enter the same state and are told apart by their stack
+	if _, to := st.Goroutine(); to == trace.GoSyscall {
+		if isCgoCall(st.Stack) {
+			return model.BlockCgo
+		}
+		return model.BlockSyscall
+	}
+	// Mutex, WaitGroup and Cond waits all park as "sync" (or "semacquire"),
+	// so the blocking frame tells a lock apart
+	reason := ClassifyWaitReason(st.Reason)
+	if reason == model.BlockSync && stackBlockingReason(st.Stack) == model.BlockMutexLock {
+		return model.BlockMutexLock
+	}
+	return reason
+}
 
-	// Map trace reasons to our blocking reasons (more robust matching)
+// ClassifyWaitReason maps a runtime wait reason to a blocking reason. It
+// accepts both the trace's block reasons (Go 1.22+, e.g. "sync" or "GC mark
+// assist wait for work") and the runtime's finer wait reasons that older
+// traces and custom runtimes report (e.g. "sync.Mutex.Lock" or "IO wait").
+func ClassifyWaitReason(reason string) model.BlockingReason {
 	r := strings.ToLower(reason)
 	switch {
-	case strings.Contains(r, "chan receive") || strings.Contains(r, "chan send"):
-		if strings.Contains(r, "receive") {
-			return model.BlockChannelRecv
-		}
+	case strings.Contains(r, "chan receive"):
+		return model.BlockChannelRecv
+	case strings.Contains(r, "chan send"):
 		return model.BlockChannelSend
-	case strings.Contains(r, "mutex") || strings.Contains(r, "lock") || strings.Contains(r, "semacquire"):
+	case strings.Contains(r, "gc assist") || strings.Contains(r, "gc mark assist"):
+		return model.BlockGCAssist
+	case strings.Contains(r, "mutex") || strings.Contains(r, ".lock"):
 		return model.BlockMutexLock
 	case strings.Contains(r, "syscall"):
 		return model.BlockSyscall
@@ -193,38 +944,144 @@ func determineBlockingReason(st trace.StateTransition) model.BlockingReason {
 		return model.BlockGC
 	case strings.Contains(r, "select"):
 		return model.BlockSelect
-	case strings.Contains(r, "network") || strings.Contains(r, "poll"):
+	case strings.Contains(r, "network") || strings.Contains(r, "poll") || strings.Contains(r, "io wait"):
 		return model.BlockNetwork
 	case strings.Contains(r, "sleep") || strings.Contains(r, "timer"):
 		return model.BlockSleep
-	case strings.Contains(r, "sync") || strings.Contains(r, "cond") || strings.Contains(r, "wait"):
+	case strings.Contains(r, "sync") || strings.Contains(r, "cond") || strings.Contains(r, "wait") || strings.Contains(r, "semacquire"):
 		return model.BlockSync
 	default:
 		return model.BlockNone
 	}
 }
 
-// contains checks if string contains substring (simple helper)
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && findSubstring(s, substr)
+// stackBlockingReason classifies a wait from the blocked goroutine's stack,
+// for status events that state a goroutine is waiting but not why. The
+// runtime's background goroutines map to the reasons their park reasons do.
+func stackBlockingReason(stack trace.Stack) model.BlockingReason {
+	for f := range stack.Frames() {
+		switch fn := f.Func; {
+		case strings.HasPrefix(fn, "runtime.chanrecv"):
+			return model.BlockChannelRecv
+		case strings.HasPrefix(fn, "runtime.chansend"):
+			return model.BlockChannelSend
+		case fn == "runtime.selectgo" || fn == "runtime.block":
+			return model.BlockSelect
+		case fn == "time.Sleep":
+			return model.BlockSleep
+		case fn == "sync.(*WaitGroup).Wait" || fn == "sync.(*Cond).Wait" || fn == "runtime.runFinalizers":
+			return model.BlockSync
+		case strings.HasPrefix(fn, "sync.(*Mutex)") || strings.HasPrefix(fn, "sync.(*RWMutex)") || strings.Contains(fn, "SemacquireMutex") || strings.Contains(fn, "SemacquireRWMutex"):
+			return model.BlockMutexLock
+		case fn == "runtime.netpollblock" || strings.HasPrefix(fn, "internal/poll."):
+			return model.BlockNetwork
+		case fn == "runtime.gcBgMarkWorker" || fn == "runtime.bgsweep" || fn == "runtime.bgscavenge" || fn == "runtime.forcegchelper":
+			return model.BlockGC
+		}
+	}
+	return model.BlockNone
 }
 
-func findSubstring(s, substr string) bool {
-	if len(substr) == 0 {
+// classifyFinalizer marks g as the finalizer goroutine when stack runs
+// finalizers or cleanups, and reports a block inside a finalizer function as
+// BlockFinalizer: it stalls every finalizer queued behind it. The goroutine's
+// idle wait for more work keeps its reason.
+func classifyFinalizer(g *model.GoroutineInfo, stack trace.Stack, reason model.BlockingReason) model.BlockingReason {
+	depth := 0
+	for f := range stack.Frames() {
+		if f.Func == "runtime.runFinalizers" || f.Func == "runtime.runCleanups" {
+			g.Finalizer = true
+			if depth > 0 {
+				return model.BlockFinalizer
+			}
+			return reason
+		}
+		depth++
+	}
+	return reason
+}
+
+// isCgoCall reports whether a syscall-state stack is a call into C. Traces
+// usually elide runtime.cgocall, leaving the generated _Cfunc_ wrapper on top.
+func isCgoCall(stack trace.Stack) bool {
+	for f := range stack.Frames() {
+		if f.Func == "runtime.cgocall" || strings.Contains(f.Func, "._Cfunc_") || strings.Contains(f.Func, "._C2func_") {
+			return true
+		}
+	}
+	return false
+}
+
+// classifySelectWake infers which case ended a select from what woke it: a
+// goroutine sending means our receive case fired, one receiving means our send
+// case did, and a runtime wake-up with no goroutine is a timer (timeout)
+func classifySelectWake(actor uint64, stack trace.Stack) model.SelectCase {
+	if actor == 0 {
+		return model.SelectTimeout
+	}
+	for f := range stack.Frames() {
+		switch {
+		case strings.Contains(f.Func, "chansend") || strings.Contains(f.Func, "selectnbsend") || strings.Contains(f.Func, "closechan"):
+			return model.SelectRecv
+		case strings.Contains(f.Func, "chanrecv") || strings.Contains(f.Func, "selectnbrecv"):
+			return model.SelectSend
+		case strings.Contains(f.Func, "time.sendTime"):
+			return model.SelectTimeout
+		}
+	}
+	return model.SelectUnknown
+}
+
+// isWaitGroupWait reports whether a sync block is a WaitGroup.Wait, either from
+// a runtime that names it in the reason or from the blocking stack
+func isWaitGroupWait(st trace.StateTransition) bool {
+	if strings.Contains(strings.ToLower(st.Reason), "waitgroup") {
 		return true
 	}
-	if len(s) < len(substr) {
-		return false
+	for f := range st.Stack.Frames() {
+		if f.Func == "sync.(*WaitGroup).Wait" {
+			return true
+		}
 	}
-	for i := 0; i <= len(s)-len(substr); i++ {
-		match := true
-		for j := 0; j < len(substr); j++ {
-			if s[i+j] != substr[j] {
-				match = false
-				break
-			}
+	return false
+}
+
+// blockSite returns the site ID of a blocked goroutine's stack, decoding each
+// distinct stack only once
+func (p *Parser) blockSite(stack trace.Stack, mu *sync.Mutex) model.SiteID {
+	mu.Lock()
+	id, ok := p.sites[stack]
+	mu.Unlock()
+	if ok {
+		return id
+	}
+	id = model.InternSite(blockFrames(stack))
+	mu.Lock()
+	p.sites[stack] = id
+	mu.Unlock()
+	return id
+}
+
+// blockFrames returns the blocking operation (innermost frame) and the first
+// frame outside the runtime and the standard sync/syscall/time packages. Only
+// the frames up to that point are decoded.
+func blockFrames(stack trace.Stack) (op, site string) {
+	for f := range stack.Frames() {
+		if op == "" {
+			op = f.Func
 		}
-		if match {
+		if !isLibraryFrame(f.Func) {
+			return op, f.Func
+		}
+	}
+	return op, ""
+}
+
+// isLibraryFrame reports whether fn belongs to a package that implements
+// blocking rather than calling it
+func isLibraryFrame(fn string) bool {
+	for _, prefix := range []string{"runtime.", "runtime/", "internal/", "sync.", "syscall.", "time."} {
+		if strings.HasPrefix(fn, prefix) {
 			return true
 		}
 	}
@@ -0,0 +1,209 @@
+package traceparser
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"runtime/trace"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goschedviz/goschedviz/internal/model"
+)
+
+func TestClassifyWaitReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   model.BlockingReason
+	}{
+		// Block reasons written by the Go 1.22+ tracer
+		{"chan send", model.BlockChannelSend},
+		{"chan receive", model.BlockChannelRecv},
+		{"select", model.BlockSelect},
+		{"sync", model.BlockSync},
+		{"sync.(*Cond).Wait", model.BlockSync},
+		{"network", model.BlockNetwork},
+		{"sleep", model.BlockSleep},
+		{"GC mark assist wait for work", model.BlockGCAssist},
+		{"GC background sweeper wait", model.BlockGC},
+		{"wait until GC ends", model.BlockGC},
+		{"GC weak to strong wait", model.BlockGC},
+		{"system goroutine wait", model.BlockSync},
+		{"synctest", model.BlockSync},
+		{"unspecified", model.BlockNone},
+		{"forever", model.BlockNone},
+		{"preempted", model.BlockNone},
+
+		// Runtime wait reasons, as older traces and custom runtimes report them
+		{"chan send (nil chan)", model.BlockChannelSend},
+		{"chan receive (nil chan)", model.BlockChannelRecv},
+		{"chan receive (synctest)", model.BlockChannelRecv},
+		{"select (no cases)", model.BlockSelect},
+		{"sync.Mutex.Lock", model.BlockMutexLock},
+		{"sync.RWMutex.Lock", model.BlockMutexLock},
+		{"sync.RWMutex.RLock", model.BlockMutexLock},
+		{"semacquire", model.BlockSync},
+		{"sync.WaitGroup.Wait", model.BlockSync},
+		{"sync.Cond.Wait", model.BlockSync},
+		{"IO wait", model.BlockNetwork},
+		{"netpoll", model.BlockNetwork},
+		{"GC assist marking", model.BlockGCAssist},
+		{"GC assist wait", model.BlockGCAssist},
+		{"GC sweep wait", model.BlockGC},
+		{"GC scavenge wait", model.BlockGC},
+		{"GC worker (idle)", model.BlockGC},
+		{"force gc (idle)", model.BlockGC},
+		{"wait for GC cycle", model.BlockGC},
+		{"timer goroutine (idle)", model.BlockSleep},
+		{"finalizer wait", model.BlockSync},
+		{"trace reader (blocked)", model.BlockNone},
+		{"syscall", model.BlockSyscall},
+		{"", model.BlockNone},
+	}
+	for _, tt := range tests {
+		if got := ClassifyWaitReason(tt.reason); got != tt.want {
+			t.Errorf("ClassifyWaitReason(%q) = %s, want %s", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestDetectTraceVersion(t *testing.T) {
+	tests := []struct {
+		header      string
+		minor       int
+		unsupported bool
+	}{
+		// Formats up to 1.21 are converted by the reader
+		{"go 1.11 trace\x00\x00\x00", 11, false},
+		{"go 1.19 trace\x00\x00\x00", 19, false},
+		{"go 1.21 trace\x00\x00\x00", 21, false},
+		{"go 1.22 trace\x00\x00\x00", 22, false},
+		// Go 1.24 writes format 1.23, and Go 1.27 format 1.26
+		{"go 1.23 trace\x00\x00\x00", 23, false},
+		{"go 1.26 trace\x00\x00\x00", 26, false},
+		{"go 1.24 trace\x00\x00\x00", 24, true},
+		{"go 1.10 trace\x00\x00\x00", 10, true},
+		{"go 1.27 trace\x00\x00\x00", 27, true},
+	}
+	for _, tt := range tests {
+		minor, err := detectTraceVersion(bufio.NewReader(strings.NewReader(tt.header)))
+		var unsupported *UnsupportedVersionError
+		if minor != tt.minor || errors.As(err, &unsupported) != tt.unsupported {
+			t.Errorf("detectTraceVersion(%q) = %d, %v; want %d, unsupported %t", tt.header, minor, err, tt.minor, tt.unsupported)
+		}
+	}
+
+	if _, err := detectTraceVersion(bufio.NewReader(strings.NewReader("not a trace at all"))); !errors.Is(err, ErrNotATrace) {
+		t.Errorf("detectTraceVersion on garbage = %v, want ErrNotATrace", err)
+	}
+}
+
+func TestKeepLongestEventsCountsDropped(t *testing.T) {
+	g := model.NewGoroutineInfo(1, 0)
+	for i, ev := range []model.BlockingEvent{
+		{Reason: model.BlockSyscall, Duration: 5},
+		{Reason: model.BlockSelect, SelectCase: model.SelectTimeout, Duration: 1},
+		{Reason: model.BlockSyscall, Duration: 2},
+		{Reason: model.BlockSelect, SelectCase: model.SelectRecv, Duration: 9},
+	} {
+		ev.StartTime = time.Duration(i * 10)
+		g.AddBlockingEvent(ev)
+	}
+
+	keepLongestEvents(g, 2)
+
+	if len(g.BlockingEvents) != 2 || g.BlockingEvents[0].Duration != 5 || g.BlockingEvents[1].Duration != 9 {
+		t.Errorf("kept %+v, want the 5 and 9 events in order", g.BlockingEvents)
+	}
+	if g.DroppedEvents != 2 || g.DroppedByReason[model.BlockSyscall] != 1 || g.DroppedByReason[model.BlockSelect] != 1 {
+		t.Errorf("dropped %d by reason %v, want one syscall and one select", g.DroppedEvents, g.DroppedByReason)
+	}
+	if g.DroppedSelects[model.SelectTimeout] != 1 || len(g.DroppedSelects) != 1 {
+		t.Errorf("dropped selects %v, want one timeout", g.DroppedSelects)
+	}
+}
+
+// TestParseClosesOpenIntervals traces a goroutine that is still blocked when
+// tracing stops; its final wait must still reach the end of the timeline
+func TestParseClosesOpenIntervals(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	stuck := make(chan struct{})
+	defer close(stuck)
+	blocked := make(chan struct{})
+	go func() {
+		close(blocked)
+		<-stuck
+	}()
+	<-blocked
+	time.Sleep(20 * time.Millisecond)
+	trace.Stop()
+
+	result, err := NewParser(WithStateIntervals()).Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var end time.Duration
+	for _, g := range result.Goroutines {
+		end = max(end, g.LastStateChange)
+	}
+	found := false
+	for _, g := range result.Goroutines {
+		if g.PendingBlock == nil || g.Preexisting || g.PendingBlock.Reason != model.BlockChannelRecv {
+			continue
+		}
+		found = true
+		last := g.Intervals[len(g.Intervals)-1]
+		if last.State != model.StateBlocked || last.Start != g.PendingBlock.StartTime || last.End < end {
+			t.Errorf("goroutine #%d ends on %+v, want its wait since %s closed at the trace end (>= %s)", g.ID, last, g.PendingBlock.StartTime, end)
+		}
+	}
+	if !found {
+		t.Fatal("no goroutine left blocked on the channel")
+	}
+}
+
+// TestParseClassifiesMutexWaits traces a contended sync.Mutex: the tracer
+// reports the wait only as "sync", so the blocking stack must make it a lock
+func TestParseClassifiesMutexWaits(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("tracing already enabled")
+	}
+
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var mu sync.Mutex
+	mu.Lock()
+	done := make(chan struct{})
+	go func() {
+		mu.Lock()
+		mu.Unlock()
+		close(done)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	mu.Unlock()
+	<-done
+	trace.Stop()
+
+	result, err := NewParser().Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, g := range result.Goroutines {
+		if g.BlockingByReason[model.BlockMutexLock] > 0 {
+			return
+		}
+	}
+	t.Error("no blocking classified as a mutex lock")
+}
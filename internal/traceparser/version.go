@@ -0,0 +1,63 @@
+package traceparser
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+)
+
+// traceFormats maps each trace format version the reader decodes, the N of
+// the "go 1.N trace" header, to the Go releases that write it. The header
+// names the format, not the Go release: formats up to 1.21 use the older
+// layout, which the reader converts, and newer formats may carry events it
+// can't decode.
+var traceFormats = map[int]string{
+	11: "Go 1.11–1.18",
+	19: "Go 1.19–1.20",
+	21: "Go 1.21",
+	22: "Go 1.22",
+	23: "Go 1.23–1.24",
+	25: "Go 1.25",
+	26: "Go 1.26–1.27",
+}
+
+// Oldest and newest trace format versions in traceFormats
+const (
+	minTraceMinor = 11
+	maxTraceMinor = 26
+)
+
+// traceHeaderLen is the length of the "go 1.NN trace\x00\x00\x00" header
+const traceHeaderLen = 16
+
+// ErrNotATrace is returned when the input doesn't start with a Go execution
+// trace header
+var ErrNotATrace = errors.New("not a Go execution trace (missing \"go 1.N trace\" header)")
+
+// UnsupportedVersionError reports a trace written in a format the reader
+// doesn't decode
+type UnsupportedVersionError struct {
+	Minor int
+}
+
+func (e *UnsupportedVersionError) Error() string {
+	return fmt.Sprintf("unsupported trace format version 1.%d; goschedviz reads formats 1.%d–1.%d, written by Go 1.11 through 1.27", e.Minor, minTraceMinor, maxTraceMinor)
+}
+
+// detectTraceVersion peeks at the trace header without consuming it and
+// returns its trace format minor version
+func detectTraceVersion(br *bufio.Reader) (int, error) {
+	header, err := br.Peek(traceHeaderLen)
+	if err != nil {
+		return 0, ErrNotATrace
+	}
+
+	var minor int
+	if _, err := fmt.Sscanf(string(header), "go 1.%d trace", &minor); err != nil {
+		return 0, ErrNotATrace
+	}
+	if _, ok := traceFormats[minor]; !ok {
+		return minor, &UnsupportedVersionError{Minor: minor}
+	}
+	return minor, nil
+}